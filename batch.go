@@ -0,0 +1,69 @@
+package rajaongkir
+
+import (
+	"context"
+	"sync"
+)
+
+const defaultBatchConcurrency = 4
+
+// CostRequest holds the parameters of a single GetCost call
+type CostRequest struct {
+	Origin      string
+	Destination string
+	Weight      int
+	Courier     string
+}
+
+// CostResult holds the outcome of a CostRequest
+type CostResult struct {
+	Request CostRequest
+	Costs   []Cost
+	Err     error
+}
+
+// WithBatchConcurrency sets how many requests GetCostsBatch
+// sends at the same time. Defaults to 4
+func WithBatchConcurrency(n int) Option {
+	return func(r *RajaOngkir) {
+		r.batchConcurrency = n
+	}
+}
+
+// GetCostsBatch fetches the shipping rates for every request in reqs
+// using a bounded pool of workers.
+// Results are returned in the same order as reqs, each with its own error,
+// so a failed request does not affect the others
+func (r *RajaOngkir) GetCostsBatch(ctx context.Context, reqs []CostRequest) []CostResult {
+	results := make([]CostResult, len(reqs))
+	workers := r.batchConcurrency
+	if workers <= 0 {
+		workers = defaultBatchConcurrency
+	}
+	if workers > len(reqs) {
+		workers = len(reqs)
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				req := reqs[i]
+				if err := ctx.Err(); err != nil {
+					results[i] = CostResult{Request: req, Err: err}
+					continue
+				}
+				costs, err := r.GetCostContext(ctx, req.Origin, req.Destination, req.Weight, req.Courier)
+				results[i] = CostResult{Request: req, Costs: costs, Err: err}
+			}
+		}()
+	}
+	for i := range reqs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,125 @@
+package rajaongkir
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const invalidCourierRes string = `{
+    "rajaongkir": {
+        "status": {
+            "code": 400,
+            "description": "Bad request. Courier tidak valid."
+        }
+    }
+}`
+
+type countingLimiter struct {
+	waits int64
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	atomic.AddInt64(&l.waits, 1)
+	return ctx.Err()
+}
+
+// setupBatchTest serves costRes unless the courier is "bad"
+// and records the highest number of requests in flight
+func setupBatchTest(opts ...Option) (*httptest.Server, *RajaOngkir, *int64) {
+	var inFlight, maxInFlight int64
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&inFlight, 1)
+		defer atomic.AddInt64(&inFlight, -1)
+		for {
+			max := atomic.LoadInt64(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt64(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond * 10)
+		r.ParseForm()
+		if r.PostForm.Get("courier") == "bad" {
+			fmt.Fprint(w, invalidCourierRes)
+			return
+		}
+		fmt.Fprint(w, costRes)
+	}
+	ts := httptest.NewTLSServer(http.HandlerFunc(handler))
+	hostname := strings.Replace(ts.URL, "https://", "", 1)
+	ro := New("APIKEY12345", hostname, ts.Client(), opts...)
+	return ts, ro, &maxInFlight
+}
+
+func TestGetCostsBatch(t *testing.T) {
+	limiter := &countingLimiter{}
+	ts, ro, maxInFlight := setupBatchTest(WithBatchConcurrency(3), WithRateLimiter(limiter))
+	defer ts.Close()
+
+	var reqs []CostRequest
+	for i := 0; i < 12; i++ {
+		courier := "jne"
+		if i%4 == 1 {
+			courier = "bad"
+		}
+		reqs = append(reqs, CostRequest{Origin: fmt.Sprint(500 + i), Destination: "114", Weight: 1700, Courier: courier})
+	}
+	results := ro.GetCostsBatch(context.Background(), reqs)
+
+	if len(results) != len(reqs) {
+		t.Fatalf("Wrong number of results. Got %d, expected %d", len(results), len(reqs))
+	}
+	for i, result := range results {
+		if result.Request != reqs[i] {
+			t.Errorf("Results out of order. Got %v at %d, expected %v", result.Request, i, reqs[i])
+		}
+		if reqs[i].Courier == "bad" {
+			if result.Err == nil {
+				t.Errorf("Expected an error for request %d", i)
+			}
+			continue
+		}
+		if result.Err != nil {
+			t.Errorf("Unexpected error for request %d: %s", i, result.Err)
+		}
+		if len(result.Costs) != 4 {
+			t.Errorf("Wrong number of costs for request %d. Got %d, expected 4", i, len(result.Costs))
+		}
+	}
+	if *maxInFlight > 3 {
+		t.Errorf("Too many requests in flight. Got %d, expected at most 3", *maxInFlight)
+	}
+	if limiter.waits != int64(len(reqs)) {
+		t.Errorf("Rate limiter not respected. Got %d waits, expected %d", limiter.waits, len(reqs))
+	}
+}
+
+func TestGetCostsBatchCancelled(t *testing.T) {
+	ts, ro, _ := setupBatchTest()
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	reqs := []CostRequest{{"501", "114", 1700, "jne"}, {"502", "114", 1700, "jne"}}
+	results := ro.GetCostsBatch(ctx, reqs)
+
+	for i, result := range results {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("Expected request %d to be cancelled. Got %v", i, result.Err)
+		}
+	}
+}
+
+func TestGetCostsBatchEmpty(t *testing.T) {
+	ro := New("APIKEY12345", "test.com", nil)
+	results := ro.GetCostsBatch(context.Background(), nil)
+	if len(results) != 0 {
+		t.Errorf("Wrong number of results. Got %d, expected 0", len(results))
+	}
+}
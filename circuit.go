@@ -0,0 +1,186 @@
+package rajaongkir
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the API while the circuit
+// breaker for an endpoint is open
+var ErrCircuitOpen = errors.New("rajaongkir: circuit open")
+
+// Defaults for the zero fields of a BreakerConfig
+const (
+	defaultBreakerFailures  = 5
+	defaultBreakerCooldown  = time.Second * 30
+	defaultBreakerSuccesses = 1
+)
+
+// BreakerConfig sets the thresholds of the circuit breaker
+type BreakerConfig struct {
+	// Failures is the number of consecutive failed calls that opens the circuit
+	Failures int
+	// Cooldown is how long the circuit stays open before a trial call is let through
+	Cooldown time.Duration
+	// Successes is the number of trial calls in a row that must succeed to close the circuit
+	Successes int
+}
+
+// CircuitState is the state of the circuit breaker for an endpoint
+type CircuitState int
+
+// List of circuit states
+const (
+	// CircuitClosed lets every call through
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails every call with ErrCircuitOpen
+	CircuitOpen
+	// CircuitHalfOpen lets one trial call through at a time
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+// WithCircuitBreaker fails calls fast with ErrCircuitOpen once an endpoint
+// keeps failing, instead of waiting for every call to time out.
+// Calls count as failed when RajaOngkir is unreachable or answers with a server error,
+// so a client created WithFallback serves estimated quotes while the circuit is open
+func WithCircuitBreaker(c BreakerConfig) Option {
+	if c.Failures <= 0 {
+		c.Failures = defaultBreakerFailures
+	}
+	if c.Cooldown <= 0 {
+		c.Cooldown = defaultBreakerCooldown
+	}
+	if c.Successes <= 0 {
+		c.Successes = defaultBreakerSuccesses
+	}
+	return func(r *RajaOngkir) {
+		r.breaker = &breaker{config: c, now: time.Now, circuits: map[string]*circuit{}}
+	}
+}
+
+// CircuitState returns the state of the circuit breaker for endpoint, such as "/cost".
+// It is always CircuitClosed for a client created without WithCircuitBreaker
+func (r *RajaOngkir) CircuitState(endpoint string) CircuitState {
+	if r.breaker == nil {
+		return CircuitClosed
+	}
+	return r.breaker.state(endpoint)
+}
+
+// breaker keeps a circuit per endpoint
+type breaker struct {
+	config   BreakerConfig
+	now      func() time.Time
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	state     CircuitState
+	failures  int
+	successes int
+	openedAt  time.Time
+	trial     bool
+}
+
+func (b *breaker) circuit(endpoint string) *circuit {
+	c, ok := b.circuits[endpoint]
+	if !ok {
+		c = &circuit{}
+		b.circuits[endpoint] = c
+	}
+	return c
+}
+
+func (b *breaker) state(endpoint string) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c := b.circuit(endpoint)
+	if c.state == CircuitOpen && b.now().Sub(c.openedAt) >= b.config.Cooldown {
+		return CircuitHalfOpen
+	}
+	return c.state
+}
+
+// allow reports whether a call to endpoint may be sent
+// and whether it is the trial call of a half-open circuit
+func (b *breaker) allow(endpoint string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c := b.circuit(endpoint)
+	if c.state == CircuitOpen && b.now().Sub(c.openedAt) >= b.config.Cooldown {
+		c.state = CircuitHalfOpen
+		c.successes = 0
+	}
+	switch {
+	case c.state == CircuitOpen, c.state == CircuitHalfOpen && c.trial:
+		return false, fmt.Errorf("%w: %s", ErrCircuitOpen, endpoint)
+	case c.state == CircuitHalfOpen:
+		c.trial = true
+		return true, nil
+	}
+	return false, nil
+}
+
+// done records the outcome of a call allowed to endpoint.
+// Calls that ended without an answer from the API, such as those canceled by the caller,
+// only give up their trial. Calls started before the circuit opened are ignored
+func (b *breaker) done(endpoint string, trial, failed, counted bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c := b.circuit(endpoint)
+	if trial {
+		c.trial = false
+	}
+	if !counted || !trial && c.state != CircuitClosed {
+		return
+	}
+	switch {
+	case failed && (trial || c.failures+1 >= b.config.Failures):
+		c.state = CircuitOpen
+		c.openedAt = b.now()
+		c.failures = 0
+	case failed:
+		c.failures++
+	case trial:
+		c.successes++
+		if c.successes >= b.config.Successes {
+			c.state = CircuitClosed
+		}
+	default:
+		c.failures = 0
+	}
+}
+
+// guard asks the breaker, if any, to let a call to endpoint through
+// and returns the function recording its outcome, nil if the call was never sent
+func (r *RajaOngkir) guard(ctx context.Context, endpoint string) (func(res *callResult), error) {
+	if r.breaker == nil {
+		return func(*callResult) {}, nil
+	}
+	trial, err := r.breaker.allow(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return func(res *callResult) {
+		if res == nil || ctx.Err() != nil {
+			r.breaker.done(endpoint, trial, false, false)
+			return
+		}
+		failed := unreachable(res.err) || res.status != nil && res.status.Code >= 500
+		r.breaker.done(endpoint, trial, failed, true)
+	}, nil
+}
@@ -0,0 +1,94 @@
+package rajaongkir
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	ts, ro, down := setupFallbackTest(WithCircuitBreaker(BreakerConfig{Failures: 2, Cooldown: time.Minute}))
+	defer ts.Close()
+	now := time.Now()
+	ro.breaker.now = func() time.Time { return now }
+
+	down.Store(true)
+	for i := 0; i < 2; i++ {
+		if _, err := ro.GetCost("501", "114", 1700, "jne"); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("Expected call %d to reach the API and fail. Got %v", i, err)
+		}
+	}
+	if s := ro.CircuitState(costEndpoint); s != CircuitOpen {
+		t.Errorf("Wrong circuit state. Got %s, expected open", s)
+	}
+	down.Store(false)
+	if _, err := ro.GetCost("501", "114", 1700, "jne"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected the open circuit to fail fast. Got %v", err)
+	}
+	if s := ro.CircuitState(cityEndpoint); s != CircuitClosed {
+		t.Errorf("Expected other endpoints to stay closed. Got %s", s)
+	}
+
+	now = now.Add(time.Minute)
+	if s := ro.CircuitState(costEndpoint); s != CircuitHalfOpen {
+		t.Errorf("Wrong circuit state after the cooldown. Got %s, expected half-open", s)
+	}
+	down.Store(true)
+	if _, err := ro.GetCost("501", "114", 1700, "jne"); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected the trial call to reach the API. Got %v", err)
+	}
+	if s := ro.CircuitState(costEndpoint); s != CircuitOpen {
+		t.Errorf("Expected a failed trial to reopen the circuit. Got %s", s)
+	}
+
+	now = now.Add(time.Minute)
+	down.Store(false)
+	if _, err := ro.GetCost("501", "114", 1700, "jne"); err != nil {
+		t.Errorf("Unexpected error on the trial call: %s", err)
+	}
+	if s := ro.CircuitState(costEndpoint); s != CircuitClosed {
+		t.Errorf("Expected a successful trial to close the circuit. Got %s", s)
+	}
+}
+
+func TestCircuitBreakerIgnoresRejections(t *testing.T) {
+	ts, ro, _ := setupTest(invalidCourierRes, WithCircuitBreaker(BreakerConfig{Failures: 1}))
+	defer ts.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := ro.GetCost("501", "114", 1700, "jne"); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("Expected rejected requests not to open the circuit")
+		}
+	}
+
+	ts, ro, down := setupFallbackTest(WithCircuitBreaker(BreakerConfig{Failures: 1}))
+	defer ts.Close()
+	down.Store(true)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ro.GetCostContext(ctx, "501", "114", 1700, "jne")
+	if s := ro.CircuitState(costEndpoint); s != CircuitClosed {
+		t.Errorf("Expected canceled calls not to open the circuit. Got %s", s)
+	}
+}
+
+func TestCircuitBreakerFallback(t *testing.T) {
+	store, _ := NewQuoteStore("")
+	ts, ro, down := setupFallbackTest(WithCircuitBreaker(BreakerConfig{Failures: 1}), WithFallback(store))
+	defer ts.Close()
+
+	if _, err := ro.GetCost("501", "114", 1700, "jne"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	down.Store(true)
+	for i := 0; i < 2; i++ {
+		costs, err := ro.GetCost("501", "114", 1700, "jne")
+		if err != nil || !costs[0].Estimated {
+			t.Errorf("Expected an estimated quote on call %d. Got %+v, %v", i, costs, err)
+		}
+	}
+	if s := ro.CircuitState(costEndpoint); s != CircuitOpen {
+		t.Errorf("Wrong circuit state. Got %s, expected open", s)
+	}
+}
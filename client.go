@@ -0,0 +1,32 @@
+package rajaongkir
+
+import "context"
+
+// Client is the set of calls RajaOngkir makes to the API.
+// Depend on it instead of *RajaOngkir to stub shipping lookups in tests,
+// see the rajaongkirmock package
+type Client interface {
+	GetProvinces() ([]Province, error)
+	GetProvincesContext(ctx context.Context) ([]Province, error)
+	GetProvince(id string) (Province, error)
+	GetProvinceContext(ctx context.Context, id string) (Province, error)
+	GetCities() ([]City, error)
+	GetCitiesContext(ctx context.Context) ([]City, error)
+	GetCitiesInProvince(provinceID string) ([]City, error)
+	GetCitiesInProvinceContext(ctx context.Context, provinceID string) ([]City, error)
+	GetCity(provinceID, cityID string) (City, error)
+	GetCityContext(ctx context.Context, provinceID, cityID string) (City, error)
+	GetSubdistricts(cityID string) ([]Subdistrict, error)
+	GetSubdistrictsContext(ctx context.Context, cityID string) ([]Subdistrict, error)
+	GetCost(origin, destination string, weight int, courier string) ([]Cost, error)
+	GetCostContext(ctx context.Context, origin, destination string, weight int, courier string) ([]Cost, error)
+	GetCostsBatch(ctx context.Context, reqs []CostRequest) []CostResult
+	QuoteShipment(ctx context.Context, s Shipment) (ShipmentQuote, error)
+	QuoteItems(ctx context.Context, origin, destination, courier string, items []Item, maxWeight int) (ShipmentQuote, error)
+	CompareOrigins(ctx context.Context, origins []string, destination string, p Parcel, couriers []string, by Ranking) (OriginComparison, error)
+	GetWaybill(waybill, courier string) (Waybill, error)
+	GetWaybillContext(ctx context.Context, waybill, courier string) (Waybill, error)
+	Verify(ctx context.Context) ([]KeyStatus, error)
+}
+
+var _ Client = (*RajaOngkir)(nil)
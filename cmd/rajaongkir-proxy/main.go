@@ -0,0 +1,57 @@
+// Command rajaongkir-proxy serves the rajaongkirproxy JSON API,
+// keeping the RajaOngkir API key on the server.
+//
+//	RAJAONGKIR_API_KEY=... rajaongkir-proxy -addr :8080 -allow-origin https://shop.example.com
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+	"github.com/GreenGeorge/go-rajaongkir/rajaongkirproxy"
+)
+
+const defaultBaseURL = "api.rajaongkir.com/starter"
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	key := flag.String("key", os.Getenv("RAJAONGKIR_API_KEY"), "API key, defaults to $RAJAONGKIR_API_KEY")
+	baseURL := flag.String("base-url", envOr("RAJAONGKIR_BASE_URL", defaultBaseURL), "API base URL, defaults to $RAJAONGKIR_BASE_URL")
+	origins := flag.String("allow-origin", "", "comma separated origins allowed to make cross-origin requests, * for any")
+	cacheTTL := flag.Duration("cache-ttl", time.Minute*10, "how long to cache responses, 0 disables caching")
+	rate := flag.Float64("rate", 5, "requests per second allowed per client, 0 disables limiting")
+	burst := flag.Int("burst", 10, "requests a client may make in a burst")
+	flag.Parse()
+
+	if *key == "" {
+		log.Fatal("an API key is required, set RAJAONGKIR_API_KEY or pass -key")
+	}
+	opts := []rajaongkirproxy.Option{
+		rajaongkirproxy.WithCacheTTL(*cacheTTL),
+		rajaongkirproxy.WithRateLimit(*rate, *burst),
+	}
+	if *origins != "" {
+		opts = append(opts, rajaongkirproxy.WithAllowedOrigins(strings.Split(*origins, ",")...))
+	}
+	h := rajaongkirproxy.NewHandler(rajaongkir.New(*key, *baseURL, nil), opts...)
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           h,
+		ReadHeaderTimeout: time.Second * 10,
+	}
+	log.Printf("listening on %s", *addr)
+	log.Fatal(srv.ListenAndServe())
+}
+
+func envOr(name, fallback string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// config holds the settings read from the config file and environment
+type config struct {
+	APIKey  string `json:"api_key"`
+	BaseURL string `json:"base_url"`
+}
+
+const defaultBaseURL = "api.rajaongkir.com/starter"
+
+// loadConfig reads path, or the default config file when path is empty,
+// and applies RAJAONGKIR_API_KEY and RAJAONGKIR_BASE_URL on top.
+// A missing default config file is not an error
+func loadConfig(path string, getenv func(string) string) (config, error) {
+	cfg := config{}
+	explicit := path != ""
+	if !explicit {
+		if dir, err := os.UserConfigDir(); err == nil {
+			path = filepath.Join(dir, "rajaongkir", "config.json")
+		}
+	}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := json.Unmarshal(data, &cfg); err != nil {
+				return cfg, fmt.Errorf("reading config %s: %w", path, err)
+			}
+		case explicit || !errors.Is(err, fs.ErrNotExist):
+			return cfg, fmt.Errorf("reading config: %w", err)
+		}
+	}
+	if key := getenv("RAJAONGKIR_API_KEY"); key != "" {
+		cfg.APIKey = key
+	}
+	if baseURL := getenv("RAJAONGKIR_BASE_URL"); baseURL != "" {
+		cfg.BaseURL = baseURL
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = defaultBaseURL
+	}
+	return cfg, nil
+}
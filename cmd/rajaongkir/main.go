@@ -0,0 +1,271 @@
+// Command rajaongkir looks up provinces, cities, shipping costs and waybills
+// from the command line.
+//
+//	rajaongkir [-config file] [-format table|json|csv] <command> [flags]
+//
+// Commands:
+//
+//	provinces                                         list provinces
+//	cities [-province ID]                             list cities, optionally in a province
+//	city -province ID -id ID                          show a city
+//	cost -from ID -to ID -weight GRAMS -courier CODE  quote shipping costs
+//	track -waybill NUMBER -courier CODE               track a shipment (Basic and Pro keys)
+//	verify                                            check the API key and show its tier
+//
+// The API key is read from $RAJAONGKIR_API_KEY or the api_key field of the config file,
+// which defaults to rajaongkir/config.json in the user config directory.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+)
+
+const usage = `usage: rajaongkir [-config file] [-format table|json|csv] <command> [flags]
+
+commands:
+  provinces                                         list provinces
+  cities [-province ID]                             list cities, optionally in a province
+  city -province ID -id ID                          show a city
+  cost -from ID -to ID -weight GRAMS -courier CODE  quote shipping costs
+  track -waybill NUMBER -courier CODE               track a shipment (Basic and Pro keys)
+  verify                                            check the API key and show its tier
+`
+
+// app holds what a command needs to run, so tests can swap them
+type app struct {
+	stdout    io.Writer
+	stderr    io.Writer
+	getenv    func(string) string
+	newClient func(cfg config) rajaongkir.Client
+}
+
+func main() {
+	a := &app{
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		getenv: os.Getenv,
+		newClient: func(cfg config) rajaongkir.Client {
+			return rajaongkir.New(cfg.APIKey, cfg.BaseURL, nil)
+		},
+	}
+	os.Exit(a.run(os.Args[1:]))
+}
+
+func (a *app) run(args []string) int {
+	fs := flag.NewFlagSet("rajaongkir", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.Usage = func() { fmt.Fprint(a.stderr, usage) }
+	configPath := fs.String("config", "", "path to the config file")
+	format := fs.String("format", "table", "output format: table, json or csv")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	out, err := newOutput(*format)
+	if err != nil {
+		fmt.Fprintln(a.stderr, err)
+		return 2
+	}
+	cfg, err := loadConfig(*configPath, a.getenv)
+	if err != nil {
+		fmt.Fprintln(a.stderr, err)
+		return 1
+	}
+
+	commands := map[string]func(ctx context.Context, c rajaongkir.Client, out output, args []string) error{
+		"provinces": a.provinces,
+		"cities":    a.cities,
+		"city":      a.city,
+		"cost":      a.cost,
+		"track":     a.track,
+		"verify":    a.verify,
+	}
+	command, ok := commands[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(a.stderr, "unknown command %q\n\n%s", fs.Arg(0), usage)
+		return 2
+	}
+	if cfg.APIKey == "" {
+		fmt.Fprintln(a.stderr, "no API key, set RAJAONGKIR_API_KEY or api_key in the config file")
+		return 1
+	}
+	err = command(context.Background(), a.newClient(cfg), out, fs.Args()[1:])
+	if errors.Is(err, flag.ErrHelp) || errors.Is(err, errUsage) {
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(a.stderr, err)
+		return 1
+	}
+	return 0
+}
+
+var errUsage = errors.New("usage")
+
+// flags parses args for a command, reporting errors to stderr
+func (a *app) flags(name string, args []string, define func(fs *flag.FlagSet)) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	define(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(a.stderr, "unexpected argument %q\n", fs.Arg(0))
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+// required reports that the flags in names must all be set
+func (a *app) required(command string, names ...string) error {
+	fmt.Fprintf(a.stderr, "%s: -%s are required\n", command, strings.Join(names, ", -"))
+	return errUsage
+}
+
+func (a *app) provinces(ctx context.Context, c rajaongkir.Client, out output, args []string) error {
+	if err := a.flags("provinces", args, func(*flag.FlagSet) {}); err != nil {
+		return err
+	}
+	provinces, err := c.GetProvincesContext(ctx)
+	if err != nil {
+		return err
+	}
+	rows := [][]string{}
+	for _, p := range provinces {
+		rows = append(rows, []string{p.ProvinceID.String(), p.Province})
+	}
+	return out.write(a.stdout, []string{"ID", "PROVINCE"}, rows, provinces)
+}
+
+func (a *app) cities(ctx context.Context, c rajaongkir.Client, out output, args []string) error {
+	var province string
+	err := a.flags("cities", args, func(fs *flag.FlagSet) {
+		fs.StringVar(&province, "province", "", "only list cities in this province ID")
+	})
+	if err != nil {
+		return err
+	}
+	var cities []rajaongkir.City
+	if province == "" {
+		cities, err = c.GetCitiesContext(ctx)
+	} else {
+		cities, err = c.GetCitiesInProvinceContext(ctx, province)
+	}
+	if err != nil {
+		return err
+	}
+	rows := [][]string{}
+	for _, city := range cities {
+		rows = append(rows, cityRow(city))
+	}
+	return out.write(a.stdout, cityHeader, rows, cities)
+}
+
+var cityHeader = []string{"ID", "NAME", "TYPE", "PROVINCE", "POSTAL CODE"}
+
+func cityRow(c rajaongkir.City) []string {
+	return []string{c.CityID.String(), c.CityName, c.Type.String(), c.Province, c.PostalCode}
+}
+
+func (a *app) city(ctx context.Context, c rajaongkir.Client, out output, args []string) error {
+	var province, id string
+	err := a.flags("city", args, func(fs *flag.FlagSet) {
+		fs.StringVar(&province, "province", "", "province ID")
+		fs.StringVar(&id, "id", "", "city ID")
+	})
+	if err != nil {
+		return err
+	}
+	if province == "" || id == "" {
+		return a.required("city", "province", "id")
+	}
+	city, err := c.GetCityContext(ctx, province, id)
+	if err != nil {
+		return err
+	}
+	return out.write(a.stdout, cityHeader, [][]string{cityRow(city)}, city)
+}
+
+func (a *app) cost(ctx context.Context, c rajaongkir.Client, out output, args []string) error {
+	var from, to, courier string
+	var weight int
+	err := a.flags("cost", args, func(fs *flag.FlagSet) {
+		fs.StringVar(&from, "from", "", "origin city ID")
+		fs.StringVar(&to, "to", "", "destination city ID")
+		fs.IntVar(&weight, "weight", 0, "weight in grams")
+		fs.StringVar(&courier, "courier", "", "courier code, e.g. jne")
+	})
+	if err != nil {
+		return err
+	}
+	if from == "" || to == "" || weight <= 0 || courier == "" {
+		return a.required("cost", "from", "to", "weight", "courier")
+	}
+	costs, err := c.GetCostContext(ctx, from, to, weight, courier)
+	if err != nil {
+		return err
+	}
+	rows := [][]string{}
+	for _, cost := range costs {
+		for _, detail := range cost.Cost {
+			rows = append(rows, []string{cost.Service, cost.Description, strconv.Itoa(detail.Value), detail.ETD, detail.Note})
+		}
+	}
+	return out.write(a.stdout, []string{"SERVICE", "DESCRIPTION", "COST", "ETD", "NOTE"}, rows, costs)
+}
+
+func (a *app) track(ctx context.Context, c rajaongkir.Client, out output, args []string) error {
+	var waybill, courier string
+	err := a.flags("track", args, func(fs *flag.FlagSet) {
+		fs.StringVar(&waybill, "waybill", "", "waybill number")
+		fs.StringVar(&courier, "courier", "", "courier code, e.g. jne")
+	})
+	if err != nil {
+		return err
+	}
+	if waybill == "" || courier == "" {
+		return a.required("track", "waybill", "courier")
+	}
+	result, err := c.GetWaybillContext(ctx, waybill, courier)
+	if err != nil {
+		return err
+	}
+	rows := [][]string{}
+	for _, m := range result.Manifest {
+		rows = append(rows, []string{m.ManifestDate, m.ManifestTime, m.CityName, m.ManifestDescription})
+	}
+	if result.Delivered {
+		d := result.DeliveryStatus
+		rows = append(rows, []string{d.PODDate, d.PODTime, "", "Delivered to " + d.PODReceiver})
+	}
+	return out.write(a.stdout, []string{"DATE", "TIME", "CITY", "DESCRIPTION"}, rows, result)
+}
+
+func (a *app) verify(ctx context.Context, c rajaongkir.Client, out output, args []string) error {
+	if err := a.flags("verify", args, func(fs *flag.FlagSet) {}); err != nil {
+		return err
+	}
+	statuses, err := c.Verify(ctx)
+	if err != nil {
+		return err
+	}
+	rows := [][]string{}
+	for _, ks := range statuses {
+		rows = append(rows, []string{ks.Key, strconv.FormatBool(ks.Valid), string(ks.Tier)})
+	}
+	return out.write(a.stdout, []string{"KEY", "VALID", "TIER"}, rows, statuses)
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+	"github.com/GreenGeorge/go-rajaongkir/rajaongkirtest"
+)
+
+func setupApp(t *testing.T, srv *rajaongkirtest.Server) (*app, *bytes.Buffer, *bytes.Buffer) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	env := map[string]string{"RAJAONGKIR_API_KEY": rajaongkirtest.DefaultKey}
+	a := &app{
+		stdout: stdout,
+		stderr: stderr,
+		getenv: func(k string) string { return env[k] },
+		newClient: func(cfg config) rajaongkir.Client {
+			return rajaongkir.New(cfg.APIKey, srv.BaseURL(), srv.Client())
+		},
+	}
+	return a, stdout, stderr
+}
+
+func TestRun(t *testing.T) {
+	srv := rajaongkirtest.NewServer(rajaongkirtest.WithTier(rajaongkir.TierBasic))
+	defer srv.Close()
+	config := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(config, []byte(`{}`), 0o600)
+
+	tables := []struct {
+		args     []string
+		contains []string
+	}{
+		{[]string{"provinces"}, []string{"ID  PROVINCE", "5   DI Yogyakarta"}},
+		{[]string{"cities", "-province", "5"}, []string{"Bantul", "Kabupaten", "55715"}},
+		{[]string{"-format", "csv", "city", "-province", "5", "-id", "39"}, []string{"ID,NAME,TYPE,PROVINCE,POSTAL CODE\n39,Bantul,Kabupaten,DI Yogyakarta,55715\n"}},
+		{[]string{"cost", "-from", "501", "-to", "114", "-weight", "1700", "-courier", "jne"}, []string{"OKE", "38000"}},
+		{[]string{"track", "-waybill", "SOCAG00183235715", "-courier", "jne"}, []string{"Manifested", "Delivered to BUDI"}},
+		{[]string{"verify"}, []string{"KEY      VALID  TIER", "***TKEY  true   basic"}},
+	}
+
+	for _, table := range tables {
+		a, stdout, stderr := setupApp(t, srv)
+		args := append([]string{"-config", config}, table.args...)
+		if code := a.run(args); code != 0 {
+			t.Errorf("Wrong exit code for %v. Got %d (%s), expected 0", table.args, code, stderr)
+			continue
+		}
+		for _, c := range table.contains {
+			if !strings.Contains(stdout.String(), c) {
+				t.Errorf("Wrong output for %v. Got %q, expected it to contain %q", table.args, stdout, c)
+			}
+		}
+	}
+}
+
+func TestRunJSON(t *testing.T) {
+	srv := rajaongkirtest.NewServer()
+	defer srv.Close()
+	a, stdout, _ := setupApp(t, srv)
+
+	if code := a.run([]string{"-config", os.DevNull, "-format", "json", "city", "-province", "5", "-id", "39"}); code != 1 {
+		t.Errorf("Wrong exit code for an unreadable config. Got %d, expected 1", code)
+	}
+
+	config := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(config, []byte(`{"api_key":"IGNORED"}`), 0o600)
+	if code := a.run([]string{"-config", config, "-format", "json", "city", "-province", "5", "-id", "39"}); code != 0 {
+		t.Fatalf("Wrong exit code. Got %d, expected 0", code)
+	}
+	var city rajaongkir.City
+	if err := json.Unmarshal(stdout.Bytes(), &city); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if city.CityName != "Bantul" || city.Type != rajaongkir.CityTypeKabupaten {
+		t.Errorf("Wrong city. Got %+v, expected Bantul", city)
+	}
+}
+
+func TestRunUsage(t *testing.T) {
+	srv := rajaongkirtest.NewServer()
+	defer srv.Close()
+
+	tables := []struct {
+		args     []string
+		code     int
+		contains string
+	}{
+		{[]string{}, 2, "usage:"},
+		{[]string{"ship"}, 2, `unknown command "ship"`},
+		{[]string{"-format", "xml", "provinces"}, 2, `unknown format "xml"`},
+		{[]string{"cost", "-from", "501"}, 2, "-from, -to, -weight, -courier are required"},
+		{[]string{"provinces", "extra"}, 2, `unexpected argument "extra"`},
+		{[]string{"track", "-waybill", "SOCAG00183235715", "-courier", "jne"}, 1, ""},
+	}
+
+	for _, table := range tables {
+		a, _, stderr := setupApp(t, srv)
+		config := filepath.Join(t.TempDir(), "config.json")
+		os.WriteFile(config, []byte(`{}`), 0o600)
+		args := append([]string{"-config", config}, table.args...)
+		if code := a.run(args); code != table.code {
+			t.Errorf("Wrong exit code for %v. Got %d, expected %d", table.args, code, table.code)
+		}
+		if !strings.Contains(stderr.String(), table.contains) {
+			t.Errorf("Wrong error for %v. Got %q, expected it to contain %q", table.args, stderr, table.contains)
+		}
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(path, []byte(`{"api_key":"FILEKEY","base_url":"example.com/basic"}`), 0o600)
+
+	cfg, err := loadConfig(path, func(string) string { return "" })
+	if err != nil || cfg.APIKey != "FILEKEY" || cfg.BaseURL != "example.com/basic" {
+		t.Errorf("Wrong config. Got %+v (%v), expected the file values", cfg, err)
+	}
+
+	env := map[string]string{"RAJAONGKIR_API_KEY": "ENVKEY"}
+	cfg, err = loadConfig(path, func(k string) string { return env[k] })
+	if err != nil || cfg.APIKey != "ENVKEY" || cfg.BaseURL != "example.com/basic" {
+		t.Errorf("Wrong config. Got %+v (%v), expected the environment key", cfg, err)
+	}
+
+	_, err = loadConfig(filepath.Join(t.TempDir(), "missing.json"), func(string) string { return "" })
+	if err == nil {
+		t.Errorf("Expected an error for a missing config file")
+	}
+}
+
+func TestRunInvalidKey(t *testing.T) {
+	srv := rajaongkirtest.NewServer()
+	defer srv.Close()
+	config := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(config, []byte(`{"api_key":"WRONGKEY"}`), 0o600)
+
+	for _, args := range [][]string{{"cities"}, {"cities", "-province", "5"}, {"city", "-province", "5", "-id", "39"}} {
+		a, stdout, stderr := setupApp(t, srv)
+		a.getenv = func(string) string { return "" }
+		if code := a.run(append([]string{"-config", config}, args...)); code != 1 {
+			t.Errorf("Wrong exit code for %v. Got %d, expected 1", args, code)
+		}
+		if !strings.Contains(stderr.String(), "Invalid key.") {
+			t.Errorf("Wrong error for %v. Got %q, expected the status description", args, stderr)
+		}
+		if stdout.Len() != 0 {
+			t.Errorf("Expected no output for %v. Got %q", args, stdout)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// output writes a command result either as rows under header or as v itself
+type output interface {
+	write(w io.Writer, header []string, rows [][]string, v any) error
+}
+
+func newOutput(format string) (output, error) {
+	switch format {
+	case "table":
+		return tableOutput{}, nil
+	case "json":
+		return jsonOutput{}, nil
+	case "csv":
+		return csvOutput{}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q, expected table, json or csv", format)
+}
+
+type tableOutput struct{}
+
+func (tableOutput) write(w io.Writer, header []string, rows [][]string, _ any) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+type jsonOutput struct{}
+
+func (jsonOutput) write(w io.Writer, _ []string, _ [][]string, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+type csvOutput struct{}
+
+func (csvOutput) write(w io.Writer, header []string, rows [][]string, _ any) error {
+	cw := csv.NewWriter(w)
+	cw.Write(header)
+	cw.WriteAll(rows)
+	return cw.Error()
+}
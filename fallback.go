@@ -0,0 +1,325 @@
+package rajaongkir
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fallback supplies estimated quotes when the API cannot be reached
+type Fallback interface {
+	Costs(req CostRequest) ([]Cost, bool)
+}
+
+// fallbackRecorder is implemented by fallbacks that learn from successful quotes
+type fallbackRecorder interface {
+	Record(req CostRequest, costs []Cost)
+}
+
+// WithFallback makes GetCost answer from fallbacks, tried in order,
+// when RajaOngkir is unreachable, fails with a server error or its circuit is open.
+// Quotes served this way have Estimated set.
+// Fallbacks that can record quotes, such as *QuoteStore, are given every successful one
+func WithFallback(fallbacks ...Fallback) Option {
+	return func(r *RajaOngkir) {
+		r.fallbacks = append(r.fallbacks, fallbacks...)
+	}
+}
+
+// unreachable reports whether err means the API could not give an answer,
+// as opposed to rejecting the request
+func unreachable(err error) bool {
+	var urlErr *url.Error
+	var responseErr *ResponseError
+	var statusErr *StatusError
+	switch {
+	case errors.Is(err, ErrCircuitOpen), errors.As(err, &urlErr), errors.As(err, &responseErr):
+		return true
+	case errors.As(err, &statusErr):
+		return statusErr.Code >= 500
+	}
+	return false
+}
+
+// fallbackCosts returns estimated costs for req if err allows falling back
+func (r *RajaOngkir) fallbackCosts(ctx context.Context, req CostRequest, err error) ([]Cost, bool) {
+	if ctx.Err() != nil || !unreachable(err) {
+		return nil, false
+	}
+	for _, f := range r.fallbacks {
+		costs, ok := f.Costs(req)
+		if !ok {
+			continue
+		}
+		estimated := make([]Cost, len(costs))
+		for i, c := range costs {
+			c.Estimated = true
+			estimated[i] = c
+		}
+		return estimated, true
+	}
+	return nil, false
+}
+
+// initFallbacks hands the client's logger to the fallbacks that report errors
+func (r *RajaOngkir) initFallbacks() {
+	for _, f := range r.fallbacks {
+		if s, ok := f.(*QuoteStore); ok && r.logger != nil {
+			s.setLogger(r.logger)
+		}
+	}
+}
+
+func (r *RajaOngkir) recordCosts(req CostRequest, costs []Cost) {
+	for _, f := range r.fallbacks {
+		if rec, ok := f.(fallbackRecorder); ok {
+			rec.Record(req, costs)
+		}
+	}
+}
+
+// quoteStoreSaveDelay batches the quotes recorded in a burst into a single write
+const quoteStoreSaveDelay = time.Second
+
+// quoteStoreMaxQuotes caps the quotes a QuoteStore keeps in memory and on disk
+const quoteStoreMaxQuotes = 10000
+
+// QuoteStore keeps the last successful quote for every cost request,
+// optionally persisted to a JSON file so it survives restarts.
+// Requests are matched on the weight their courier bills, so a quote for 1.2 kg
+// with JNE also answers one for 1.7 kg. Once it holds 10000 quotes, recording
+// another drops the one recorded longest ago.
+// Changes are written in the background shortly after they are recorded,
+// call Flush to write them at once, e.g. before the program exits
+type QuoteStore struct {
+	mu        sync.Mutex
+	path      string
+	quotes    map[string]storedQuote
+	max       int
+	now       func() time.Time
+	dirty     bool
+	scheduled bool
+	delay     time.Duration
+	logger    *logger
+	// saveMu keeps writes in the order their snapshots were taken
+	saveMu sync.Mutex
+}
+
+type storedQuote struct {
+	Request  CostRequest `json:"request"`
+	Costs    []Cost      `json:"costs"`
+	Recorded time.Time   `json:"recorded"`
+}
+
+func quoteKey(req CostRequest) string {
+	weight := Weight(req.Weight).Billable(req.Courier)
+	return fmt.Sprintf("%s:%s:%d:%s", req.Origin, req.Destination, weight, strings.ToLower(req.Courier))
+}
+
+// NewQuoteStore returns a QuoteStore saved to path, loading any quotes already there.
+// An empty path keeps quotes in memory only
+func NewQuoteStore(path string) (*QuoteStore, error) {
+	s := &QuoteStore{path: path, quotes: map[string]storedQuote{}, max: quoteStoreMaxQuotes, now: time.Now, delay: quoteStoreSaveDelay}
+	if path == "" {
+		return s, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	stored := []storedQuote{}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("rajaongkir: reading quote store %s: %w", path, err)
+	}
+	for _, q := range stored {
+		if prev, ok := s.quotes[quoteKey(q.Request)]; !ok || prev.Recorded.Before(q.Recorded) {
+			s.quotes[quoteKey(q.Request)] = q
+		}
+	}
+	for len(s.quotes) > s.max {
+		s.evict()
+	}
+	return s, nil
+}
+
+// Costs returns the last quote recorded for req
+func (s *QuoteStore) Costs(req CostRequest) ([]Cost, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q, ok := s.quotes[quoteKey(req)]
+	return q.Costs, ok
+}
+
+// Record saves costs as the last quote for req and schedules a write if it changed.
+// Write errors are logged by the clients created WithLogger and WithFallback(s)
+func (s *QuoteStore) Record(req CostRequest, costs []Cost) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := quoteKey(req)
+	if prev, ok := s.quotes[key]; ok && reflect.DeepEqual(prev.Costs, costs) {
+		return
+	}
+	if _, ok := s.quotes[key]; !ok && len(s.quotes) >= s.max {
+		s.evict()
+	}
+	s.quotes[key] = storedQuote{Request: req, Costs: costs, Recorded: s.now()}
+	s.dirty = true
+	if s.path != "" && !s.scheduled {
+		s.scheduled = true
+		time.AfterFunc(s.delay, func() {
+			if err := s.Flush(); err != nil {
+				s.logError(err)
+			}
+		})
+	}
+}
+
+// evict drops the quote recorded longest ago
+func (s *QuoteStore) evict() {
+	oldest := ""
+	for key, q := range s.quotes {
+		if oldest == "" || q.Recorded.Before(s.quotes[oldest].Recorded) {
+			oldest = key
+		}
+	}
+	delete(s.quotes, oldest)
+}
+
+// Flush writes the quotes recorded since the last write
+func (s *QuoteStore) Flush() error {
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+	s.mu.Lock()
+	s.scheduled = false
+	if s.path == "" || !s.dirty {
+		s.mu.Unlock()
+		return nil
+	}
+	stored := make([]storedQuote, 0, len(s.quotes))
+	for _, q := range s.quotes {
+		stored = append(stored, q)
+	}
+	s.dirty = false
+	s.mu.Unlock()
+
+	if err := s.save(stored); err != nil {
+		s.mu.Lock()
+		s.dirty = true
+		s.mu.Unlock()
+		return fmt.Errorf("rajaongkir: writing quote store %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// save writes stored to a temporary file and renames it over path,
+// so a crash never leaves a half written store behind
+func (s *QuoteStore) save(stored []storedQuote) error {
+	sort.Slice(stored, func(i, j int) bool {
+		return quoteKey(stored[i].Request) < quoteKey(stored[j].Request)
+	})
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func (s *QuoteStore) setLogger(l *logger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logger = l
+}
+
+func (s *QuoteStore) logError(err error) {
+	s.mu.Lock()
+	l := s.logger
+	s.mu.Unlock()
+	if l == nil || l.logger == nil {
+		return
+	}
+	l.logger.LogAttrs(context.Background(), l.failureLevel, "rajaongkir quote store",
+		slog.String("path", s.path),
+		slog.String("error", err.Error()),
+	)
+}
+
+// TableRate is a static price per kilogram for a courier service
+// between two provinces
+type TableRate struct {
+	OriginProvince      ProvinceID `json:"origin_province"`
+	DestinationProvince ProvinceID `json:"destination_province"`
+	Courier             string     `json:"courier"`
+	Service             string     `json:"service"`
+	Description         string     `json:"description"`
+	PerKilogram         int        `json:"per_kilogram"`
+	ETD                 string     `json:"etd"`
+}
+
+// RateTable quotes from static rates per province pair
+type RateTable struct {
+	provinces map[CityID]ProvinceID
+	rates     []TableRate
+}
+
+// NewRateTable returns a RateTable pricing shipments between cities,
+// which are mapped to their provinces to look up rates
+func NewRateTable(cities []City, rates []TableRate) *RateTable {
+	t := &RateTable{provinces: make(map[CityID]ProvinceID, len(cities)), rates: rates}
+	for _, c := range cities {
+		t.provinces[c.CityID] = c.ProvinceID
+	}
+	return t
+}
+
+// Costs prices req from the rates for its province pair and courier,
+// charged on the weight the courier bills, see Weight.Billable
+func (t *RateTable) Costs(req CostRequest) ([]Cost, bool) {
+	origin, ok := t.provinces[CityID(req.Origin)]
+	if !ok {
+		return nil, false
+	}
+	destination, ok := t.provinces[CityID(req.Destination)]
+	if !ok {
+		return nil, false
+	}
+	billable := Weight(req.Weight).Billable(req.Courier)
+	costs := []Cost{}
+	for _, rate := range t.rates {
+		if rate.OriginProvince != origin || rate.DestinationProvince != destination || !strings.EqualFold(rate.Courier, req.Courier) {
+			continue
+		}
+		c := Cost{Service: rate.Service, Description: rate.Description}
+		c.Cost = append(c.Cost, struct {
+			Value int    `json:"value"`
+			ETD   string `json:"etd"`
+			Note  string `json:"note"`
+		}{Value: rate.PerKilogram * billable.Grams() / 1000, ETD: rate.ETD})
+		costs = append(costs, c)
+	}
+	return costs, len(costs) > 0
+}
@@ -0,0 +1,233 @@
+package rajaongkir
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// setupFallbackTest returns a client whose API answers costRes
+// until down is set, then fails with a gateway error page
+func setupFallbackTest(opts ...Option) (*httptest.Server, *RajaOngkir, *atomic.Bool) {
+	down := &atomic.Bool{}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, "<html><body>502 Bad Gateway</body></html>")
+			return
+		}
+		fmt.Fprint(w, costRes)
+	}
+	ts := httptest.NewTLSServer(http.HandlerFunc(handler))
+	hostname := strings.Replace(ts.URL, "https://", "", 1)
+	return ts, New("APIKEY12345", hostname, ts.Client(), opts...), down
+}
+
+func TestFallbackQuoteStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quotes.json")
+	store, err := NewQuoteStore(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	ts, ro, down := setupFallbackTest(WithFallback(store))
+	defer ts.Close()
+
+	live, err := ro.GetCost("501", "114", 1700, "jne")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if live[0].Estimated {
+		t.Errorf("Expected a live quote not to be estimated")
+	}
+
+	if err := store.Flush(); err != nil {
+		t.Fatalf("Unexpected error flushing the store: %s", err)
+	}
+	down.Store(true)
+	reloaded, err := NewQuoteStore(path)
+	if err != nil {
+		t.Fatalf("Unexpected error reloading the store: %s", err)
+	}
+	for _, r := range []*RajaOngkir{ro, New(ro.apiKey, ro.baseURL, ro.client, WithFallback(reloaded))} {
+		costs, err := r.GetCost("501", "114", 1700, "jne")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(costs) != len(live) || !costs[0].Estimated || costs[0].Cost[0].Value != live[0].Cost[0].Value {
+			t.Errorf("Wrong fallback quote. Got %+v, expected %+v marked estimated", costs, live)
+		}
+	}
+
+	if costs, err := ro.GetCost("501", "114", 1200, "JNE"); err != nil || costs[0].Cost[0].Value != live[0].Cost[0].Value {
+		t.Errorf("Expected the quote for the same billable weight. Got %+v, %v", costs, err)
+	}
+	if _, err := ro.GetCost("501", "114", 2100, "jne"); err == nil {
+		t.Errorf("Expected an error for a request never quoted before")
+	}
+}
+
+func TestQuoteStoreMaxQuotes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quotes.json")
+	store, _ := NewQuoteStore(path)
+	store.max = 2
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { now = now.Add(time.Second); return now }
+	reqs := []CostRequest{
+		{Origin: "501", Destination: "114", Weight: 1000, Courier: "jne"},
+		{Origin: "501", Destination: "114", Weight: 2000, Courier: "jne"},
+		{Origin: "501", Destination: "114", Weight: 3000, Courier: "jne"},
+	}
+
+	for _, req := range reqs {
+		store.Record(req, []Cost{{Service: "REG"}})
+	}
+	if _, ok := store.Costs(reqs[0]); ok {
+		t.Errorf("Expected the oldest quote to be dropped")
+	}
+	for _, req := range reqs[1:] {
+		if _, ok := store.Costs(req); !ok {
+			t.Errorf("Expected the quote for %d g to be kept", req.Weight)
+		}
+	}
+	if err := store.Flush(); err != nil {
+		t.Fatalf("Unexpected error flushing the store: %s", err)
+	}
+	stored := []storedQuote{}
+	data, _ := os.ReadFile(path)
+	if err := json.Unmarshal(data, &stored); err != nil || len(stored) != 2 {
+		t.Errorf("Wrong quotes written. Got %d, %v, expected 2", len(stored), err)
+	}
+}
+
+// eventually polls cond for up to a second
+func eventually(cond func() bool) bool {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond * 5) {
+		if cond() {
+			return true
+		}
+	}
+	return cond()
+}
+
+func TestQuoteStoreBackgroundWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quotes.json")
+	store, _ := NewQuoteStore(path)
+	store.delay = time.Millisecond * 50
+	req := CostRequest{Origin: "501", Destination: "114", Weight: 1700, Courier: "jne"}
+
+	store.Record(req, []Cost{{Service: "REG"}})
+	if _, err := os.Stat(path); err == nil {
+		t.Errorf("Expected the write to happen after the quote was recorded")
+	}
+	if !eventually(func() bool { _, err := os.Stat(path); return err == nil }) {
+		t.Fatalf("Expected the store to be written in the background")
+	}
+	reloaded, _ := NewQuoteStore(path)
+	if costs, ok := reloaded.Costs(req); !ok || costs[0].Service != "REG" {
+		t.Errorf("Wrong quote after reloading. Got %+v", costs)
+	}
+
+	buf := &syncBuffer{}
+	l := slog.New(slog.NewTextHandler(buf, nil))
+	broken, _ := NewQuoteStore(filepath.Join(t.TempDir(), "missing", "quotes.json"))
+	broken.delay = 0
+	New("APIKEY12345", "test.com", nil, WithFallback(broken), WithLogger(l))
+	broken.Record(req, []Cost{{Service: "REG"}})
+	if !eventually(func() bool { return strings.Contains(buf.String(), "rajaongkir quote store") }) {
+		t.Errorf("Expected the write error to be logged. Got %q", buf.String())
+	}
+	if err := broken.Flush(); err == nil {
+		t.Errorf("Expected Flush to report the write error")
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestFallbackRateTable(t *testing.T) {
+	cities := []City{{CityID: "501", ProvinceID: "5"}, {CityID: "114", ProvinceID: "1"}}
+	rates := NewRateTable(cities, []TableRate{
+		{OriginProvince: "5", DestinationProvince: "1", Courier: "jne", Service: "REG", PerKilogram: 22000, ETD: "2-3"},
+		{OriginProvince: "5", DestinationProvince: "1", Courier: "jne", Service: "YES", PerKilogram: 49000, ETD: "1-1"},
+		{OriginProvince: "1", DestinationProvince: "5", Courier: "jne", Service: "REG", PerKilogram: 25000, ETD: "2-3"},
+	})
+
+	tables := []struct {
+		req      CostRequest
+		expected []int
+	}{
+		{CostRequest{Origin: "501", Destination: "114", Weight: 1700, Courier: "jne"}, []int{44000, 98000}},
+		{CostRequest{Origin: "114", Destination: "501", Weight: 1000, Courier: "JNE"}, []int{25000}},
+		{CostRequest{Origin: "501", Destination: "114", Weight: 1700, Courier: "pos"}, nil},
+		{CostRequest{Origin: "501", Destination: "39", Weight: 1700, Courier: "jne"}, nil},
+	}
+
+	for _, table := range tables {
+		costs, ok := rates.Costs(table.req)
+		if ok != (table.expected != nil) || len(costs) != len(table.expected) {
+			t.Errorf("Wrong costs for %+v. Got %+v, expected %v", table.req, costs, table.expected)
+			continue
+		}
+		for i, c := range costs {
+			if c.Cost[0].Value != table.expected[i] {
+				t.Errorf("Wrong cost for %+v. Got %d, expected %d", table.req, c.Cost[0].Value, table.expected[i])
+			}
+		}
+	}
+}
+
+func TestFallbackOnlyWhenUnreachable(t *testing.T) {
+	cities := []City{{CityID: "501", ProvinceID: "5"}, {CityID: "114", ProvinceID: "1"}}
+	rates := NewRateTable(cities, []TableRate{
+		{OriginProvince: "5", DestinationProvince: "1", Courier: "jne", Service: "REG", PerKilogram: 22000, ETD: "2-3"},
+	})
+
+	ts, ro, _ := setupTest(invalidCourierRes, WithFallback(rates))
+	defer ts.Close()
+	if _, err := ro.GetCost("501", "114", 1700, "jne"); err == nil {
+		t.Errorf("Expected a rejected request not to fall back")
+	}
+	if _, err := ro.GetCost("501", "114", 0, "jne"); err == nil {
+		t.Errorf("Expected an invalid weight not to fall back")
+	}
+
+	ts, ro, down := setupFallbackTest(WithFallback(rates))
+	defer ts.Close()
+	down.Store(true)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ro.GetCostContext(ctx, "501", "114", 1700, "jne"); err == nil {
+		t.Errorf("Expected a canceled request not to fall back")
+	}
+	costs, err := ro.GetCost("501", "114", 1700, "jne")
+	if err != nil || len(costs) != 1 || !costs[0].Estimated || costs[0].Cost[0].Value != 44000 {
+		t.Errorf("Wrong fallback from the rate table. Got %+v, %v", costs, err)
+	}
+}
@@ -0,0 +1,103 @@
+package rajaongkir
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// errHedgeLost cancels the attempt whose response is no longer needed
+var errHedgeLost = errors.New("rajaongkir: other attempt answered first")
+
+// WithHedging sends a second attempt of a lookup when the first has not answered
+// after delay, and uses whichever succeeds first, canceling the other.
+// Only idempotent lookups are hedged: provinces, cities and costs.
+// Each attempt of a hedged call is traced in its own child span.
+// Metrics given WithMetrics that implement HedgeMetrics are told how often hedges win
+func WithHedging(delay time.Duration) Option {
+	return func(r *RajaOngkir) {
+		r.hedgeDelay = delay
+	}
+}
+
+func hedgeable(endpoint string) bool {
+	path, _, _ := strings.Cut(endpoint, "?")
+	switch path {
+	case provinceEndpoint, cityEndpoint, costEndpoint:
+		return true
+	}
+	return false
+}
+
+type hedgeOutcome struct {
+	vs    interface{}
+	err   error
+	hedge bool
+}
+
+// answered reports whether o can be used instead of waiting for the other attempt
+func (o hedgeOutcome) answered() bool {
+	if o.err != nil {
+		return false
+	}
+	re, ok := o.vs.(responder)
+	return !ok || re.responseStatus().Code < 500
+}
+
+// hedge races the first attempt against one sent after r.hedgeDelay.
+// Each attempt decodes into its own copy of vs and is traced in its own span,
+// so the attempts never record on the caller's span at the same time.
+// The one used is copied into vs. If neither answers, the outcome of the first to finish is returned
+func (r *RajaOngkir) hedge(ctx context.Context, method, endpoint, payload string, vs interface{}) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(errHedgeLost)
+	outcomes := make(chan hedgeOutcome, 2)
+	send := func(hedge bool) {
+		v := reflect.New(reflect.TypeOf(vs).Elem()).Interface()
+		attemptCtx, span := r.startSpan(ctx, "Attempt")
+		span.SetAttribute("rajaongkir.hedge", hedge)
+		go func() {
+			err := r.call(attemptCtx, method, endpoint, payload, v)
+			if errors.Is(context.Cause(ctx), errHedgeLost) {
+				span.SetAttribute("rajaongkir.hedge.lost", true)
+				finishSpan(span, nil)
+			} else {
+				finishSpan(span, err)
+			}
+			outcomes <- hedgeOutcome{vs: v, err: err, hedge: hedge}
+		}()
+	}
+
+	send(false)
+	timer := time.NewTimer(r.hedgeDelay)
+	defer timer.Stop()
+	pending, hedged := 1, false
+	var first *hedgeOutcome
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			pending++
+			hedged = true
+			send(true)
+		case o := <-outcomes:
+			pending--
+			if o.answered() {
+				if hedged {
+					r.observeHedge(endpoint, o.hedge)
+				}
+				reflect.ValueOf(vs).Elem().Set(reflect.ValueOf(o.vs).Elem())
+				return nil
+			}
+			if first == nil {
+				first = &o
+			}
+		}
+	}
+	if hedged {
+		r.observeHedge(endpoint, false)
+	}
+	reflect.ValueOf(vs).Elem().Set(reflect.ValueOf(first.vs).Elem())
+	return first.err
+}
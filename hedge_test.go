@@ -0,0 +1,140 @@
+package rajaongkir
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeHedgeMetrics struct {
+	fakeMetrics
+	mu     sync.Mutex
+	hedges []bool
+}
+
+func (m *fakeHedgeMetrics) ObserveRequest(endpoint string, status int, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fakeMetrics.ObserveRequest(endpoint, status, duration, err)
+}
+
+func (m *fakeHedgeMetrics) ObserveHedge(endpoint string, won bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hedges = append(m.hedges, won)
+}
+
+// setupHedgeTest returns a client whose API stalls on the first request
+// until it is canceled, and answers the next ones at once
+func setupHedgeTest(opts ...Option) (*httptest.Server, *RajaOngkir, *atomic.Int32) {
+	calls := &atomic.Int32{}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		// Reading the body lets the server notice the client going away
+		r.ParseForm()
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second * 5):
+			}
+			return
+		}
+		fmt.Fprint(w, costRes)
+	}
+	ts := httptest.NewTLSServer(http.HandlerFunc(handler))
+	hostname := strings.Replace(ts.URL, "https://", "", 1)
+	return ts, New("APIKEY12345", hostname, ts.Client(), opts...), calls
+}
+
+func TestHedging(t *testing.T) {
+	m := &fakeHedgeMetrics{}
+	ts, ro, calls := setupHedgeTest(WithHedging(time.Millisecond*20), WithMetrics(m))
+	defer ts.Close()
+
+	start := time.Now()
+	costs, err := ro.GetCost("501", "114", 1700, "jne")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(costs) != 4 || costs[0].Cost[0].Value != 38000 {
+		t.Errorf("Wrong costs from the hedge. Got %+v", costs)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("Expected the hedge to answer quickly. Took %s", d)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("Wrong number of attempts. Got %d, expected 2", n)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.hedges) != 1 || !m.hedges[0] {
+		t.Errorf("Expected a winning hedge to be observed. Got %v", m.hedges)
+	}
+	if len(m.observations) != 1 || m.observations[0].err != nil {
+		t.Errorf("Expected only the winning attempt to be observed. Got %+v", m.observations)
+	}
+}
+
+func TestHedgingSpans(t *testing.T) {
+	tracer := &fakeTracer{}
+	ts, ro, _ := setupHedgeTest(WithHedging(time.Millisecond*20), WithTracer(tracer))
+	defer ts.Close()
+
+	if _, err := ro.GetCostContext(context.Background(), "501", "114", 1700, "jne"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	// The span of the losing attempt is still in use until it notices the cancellation,
+	// only the spans that are done with are inspected
+	if len(tracer.spans) != 3 {
+		t.Fatalf("Wrong number of spans. Got %d, expected 3", len(tracer.spans))
+	}
+	call, first, hedge := tracer.spans[0], tracer.spans[1], tracer.spans[2]
+	if first.name != "rajaongkir.Attempt" || first.parent != call || hedge.name != "rajaongkir.Attempt" || hedge.parent != call {
+		t.Errorf("Expected a child span per attempt. Got %s and %s", first.name, hedge.name)
+	}
+	if !hedge.ended || hedge.attributes["rajaongkir.hedge"] != true || hedge.attributes["rajaongkir.status"] != 200 {
+		t.Errorf("Wrong span for the winning hedge. Got %+v", hedge)
+	}
+	if _, ok := call.attributes["rajaongkir.status"]; ok {
+		t.Errorf("Expected the attempts not to record on the caller's span. Got %v", call.attributes)
+	}
+}
+
+func TestHedgingFastResponse(t *testing.T) {
+	m := &fakeHedgeMetrics{}
+	ts, ro, rec := setupTest(costRes, WithHedging(time.Second), WithMetrics(m))
+	defer ts.Close()
+
+	if _, err := ro.GetCost("501", "114", 1700, "jne"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if rec.receivedEndpoint != costEndpoint {
+		t.Errorf("Wrong endpoint. Got %s, expected %s", rec.receivedEndpoint, costEndpoint)
+	}
+	if len(m.hedges) != 0 || len(m.observations) != 1 {
+		t.Errorf("Expected no hedge for a fast response. Got %v hedges, %d calls", m.hedges, len(m.observations))
+	}
+}
+
+func TestHedgeable(t *testing.T) {
+	tables := []struct {
+		endpoint string
+		expected bool
+	}{
+		{"/province?id=12", true},
+		{"/city?province=5", true},
+		{"/cost", true},
+		{"/waybill", false},
+	}
+
+	for _, table := range tables {
+		if got := hedgeable(table.endpoint); got != table.expected {
+			t.Errorf("Wrong hedgeable for %s. Got %t, expected %t", table.endpoint, got, table.expected)
+		}
+	}
+}
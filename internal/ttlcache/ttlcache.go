@@ -0,0 +1,121 @@
+// Package ttlcache is a small in-memory cache whose entries expire,
+// shared by the proxy and gRPC servers.
+package ttlcache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+)
+
+// MaxEntries bounds the memory used by a Cache
+const MaxEntries = 10000
+
+type entry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// Cache keeps values for TTL. A TTL of 0 or less disables caching
+type Cache[V any] struct {
+	TTL time.Duration
+	// Now returns the current time, tests may replace it
+	Now func() time.Time
+	// Metrics, if set, is told whether each Load was a hit
+	Metrics rajaongkir.CacheMetrics
+	// Tracer, if set, traces each Load in a span
+	Tracer rajaongkir.Tracer
+
+	mu      sync.Mutex
+	entries map[string]entry[V]
+}
+
+// New returns an empty Cache keeping values for ttl
+func New[V any](ttl time.Duration) *Cache[V] {
+	return &Cache[V]{TTL: ttl, Now: time.Now, entries: map[string]entry[V]{}}
+}
+
+// Get returns the value cached for key if it has not expired
+func (c *Cache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if !c.Now().Before(e.expires) {
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Set caches value for key
+func (c *Cache[V]) Set(key string, value V) {
+	if c.TTL <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.Now()
+	if len(c.entries) >= MaxEntries {
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	// Still full, make room by evicting whatever map iteration yields first
+	for k := range c.entries {
+		if len(c.entries) < MaxEntries {
+			break
+		}
+		delete(c.entries, k)
+	}
+	c.entries[key] = entry[V]{value: value, expires: now.Add(c.TTL)}
+}
+
+// Estimated is implemented by values that may hold quotes from a rajaongkir.Fallback
+type Estimated interface {
+	Estimated() bool
+}
+
+// Load returns the value cached for key, or calls f with ctx and caches its result.
+// endpoint names the lookup in metrics and spans.
+// Values holding estimated quotes are returned but not cached: they stand in
+// for an outage, and real quotes should be served again as soon as it ends
+func (c *Cache[V]) Load(ctx context.Context, endpoint, key string, f func(ctx context.Context) (V, error)) (value V, err error) {
+	var span rajaongkir.Span
+	if c.Tracer != nil {
+		ctx, span = c.Tracer.Start(ctx, "rajaongkir.Cache")
+		span.SetAttribute("rajaongkir.endpoint", endpoint)
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+			}
+			span.End()
+		}()
+	}
+	value, hit := c.Get(key)
+	if span != nil {
+		span.SetAttribute("rajaongkir.cache_hit", hit)
+	}
+	if c.Metrics != nil {
+		c.Metrics.ObserveCache(endpoint, hit)
+	}
+	if hit {
+		return value, nil
+	}
+	value, err = f(ctx)
+	if err != nil {
+		return value, err
+	}
+	if e, ok := any(value).(Estimated); !ok || !e.Estimated() {
+		c.Set(key, value)
+	}
+	return value, nil
+}
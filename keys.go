@@ -0,0 +1,248 @@
+package rajaongkir
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// KeyStrategy decides which key of a pool is used for the next call
+type KeyStrategy int
+
+// List of key strategies
+const (
+	// RoundRobin uses the keys in turn
+	RoundRobin KeyStrategy = iota
+	// LeastUsed uses the key that has made the fewest calls
+	LeastUsed
+)
+
+// ErrNoAPIKey is returned by every call of a client created without a non-empty API key
+var ErrNoAPIKey = errors.New("rajaongkir: no API key configured")
+
+// wib is the timezone RajaOngkir resets daily quotas in
+var wib = time.FixedZone("WIB", 7*60*60)
+
+// keyBackoff is how long a key RajaOngkir throttles rests before it is used again
+const keyBackoff = 30 * time.Second
+
+// rejection is how RajaOngkir refused the key a call was made with
+type rejection int
+
+const (
+	// accepted means the key was not refused, whatever the answer to the call
+	accepted rejection = iota
+	// throttled means the key made too many requests and rests for keyBackoff
+	throttled
+	// refused means the key is invalid or used up its daily quota and rests until midnight WIB
+	refused
+)
+
+// KeyStats is the usage of a key in the pool
+type KeyStats struct {
+	// Key is redacted to its last four characters
+	Key        string
+	Requests   int
+	Rejections int
+	// RestingUntil is when a key rejected by RajaOngkir is used again, zero while it is in rotation
+	RestingUntil time.Time
+}
+
+// WithAPIKeys pools keys with the one given to New and spreads calls over them
+// according to strategy. A key RajaOngkir rejects as invalid or over its quota
+// rests until the quota resets at midnight WIB, a key it throttles rests for a
+// few seconds, and the call is retried with another key. Once every key has
+// been rejected the last answer is returned
+func WithAPIKeys(strategy KeyStrategy, keys ...string) Option {
+	return func(r *RajaOngkir) {
+		p := &keyPool{strategy: strategy, now: time.Now}
+		seen := map[string]bool{}
+		for _, key := range append([]string{r.apiKey}, keys...) {
+			if key != "" && !seen[key] {
+				seen[key] = true
+				p.keys = append(p.keys, &poolKey{key: key})
+			}
+		}
+		r.keys = p
+	}
+}
+
+// KeyStats returns the usage of every key, in the order they were given.
+// It is nil for a client created without WithAPIKeys
+func (r *RajaOngkir) KeyStats() []KeyStats {
+	if r.keys == nil {
+		return nil
+	}
+	return r.keys.stats()
+}
+
+type keyPool struct {
+	strategy KeyStrategy
+	now      func() time.Time
+	mu       sync.Mutex
+	keys     []*poolKey
+	next     int
+}
+
+type poolKey struct {
+	key          string
+	requests     int
+	rejections   int
+	restingUntil time.Time
+}
+
+// pick returns the key for the next call, skipping tried keys.
+// Resting keys are only used if no other key is left
+func (p *keyPool) pick(tried map[string]bool) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	best, bestResting := -1, false
+	for i := range p.keys {
+		j := (p.next + i) % len(p.keys)
+		k := p.keys[j]
+		if tried[k.key] {
+			continue
+		}
+		resting := now.Before(k.restingUntil)
+		if best < 0 || bestResting && !resting ||
+			bestResting == resting && p.strategy == LeastUsed && k.requests < p.keys[best].requests {
+			best, bestResting = j, resting
+		}
+	}
+	if best < 0 {
+		return "", false
+	}
+	p.next = (best + 1) % len(p.keys)
+	p.keys[best].requests++
+	return p.keys[best].key, true
+}
+
+// done records how RajaOngkir answered a call made with key
+func (p *keyPool) done(key string, r rejection) {
+	if r == accepted {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	for _, k := range p.keys {
+		if k.key != key {
+			continue
+		}
+		k.rejections++
+		if r == throttled {
+			k.restingUntil = now.Add(keyBackoff)
+			continue
+		}
+		y, m, d := now.In(wib).Date()
+		k.restingUntil = time.Date(y, m, d+1, 0, 0, 0, 0, wib)
+	}
+}
+
+// use counts a call made with key, which was chosen without pick
+func (p *keyPool) use(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, k := range p.keys {
+		if k.key == key {
+			k.requests++
+		}
+	}
+}
+
+// list returns the keys in the order they were given
+func (p *keyPool) list() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	keys := make([]string, len(p.keys))
+	for i, k := range p.keys {
+		keys[i] = k.key
+	}
+	return keys
+}
+
+func (p *keyPool) stats() []KeyStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	stats := make([]KeyStats, len(p.keys))
+	for i, k := range p.keys {
+		stats[i] = KeyStats{Key: redactKey(k.key), Requests: k.requests, Rejections: k.rejections}
+		if now.Before(k.restingUntil) {
+			stats[i].RestingUntil = k.restingUntil
+		}
+	}
+	return stats
+}
+
+// keyRejected returns how the response in vs refuses the key itself
+func keyRejected(vs interface{}) rejection {
+	re, ok := vs.(responder)
+	if !ok {
+		return accepted
+	}
+	return keyRejection(re.responseStatus())
+}
+
+// rejectsKey reports whether s refuses the key a call was made with
+func rejectsKey(s *status) bool {
+	return keyRejection(s) != accepted
+}
+
+// keyRejection returns how s refuses the key a call was made with.
+// Only an invalid key or a used up daily quota refuse it; other bad requests,
+// such as a weight over the limit, are about the request and keep the key in rotation
+func keyRejection(s *status) rejection {
+	description := strings.ToLower(s.Description)
+	switch {
+	case s.Code == 429:
+		return throttled
+	case s.Code == 401, s.Code == 403:
+		return refused
+	case s.Code == 400 && strings.HasPrefix(description, "invalid key"):
+		return refused
+	case s.Code == 400 && (strings.Contains(description, "daily limit") || strings.Contains(description, "quota")):
+		return refused
+	}
+	return accepted
+}
+
+// call makes an attempt with a key from the pool, failing over to the other keys
+// while RajaOngkir rejects them
+func (r *RajaOngkir) call(ctx context.Context, method, endpoint, payload string, vs interface{}) error {
+	if r.keys == nil {
+		if r.apiKey == "" {
+			return ErrNoAPIKey
+		}
+		return r.attempt(ctx, r.apiKey, 1, method, endpoint, payload, vs)
+	}
+	tried := map[string]bool{}
+	var err error
+	for n := 1; ; n++ {
+		key, ok := r.keys.pick(tried)
+		if !ok && n == 1 {
+			return ErrNoAPIKey
+		}
+		if !ok {
+			return err
+		}
+		if n > 1 {
+			reflect.ValueOf(vs).Elem().SetZero()
+			r.observeRetry(endpoint)
+		}
+		err = r.attempt(ctx, key, n, method, endpoint, payload, vs)
+		rejected := accepted
+		if err == nil {
+			rejected = keyRejected(vs)
+		}
+		r.keys.done(key, rejected)
+		if rejected == accepted {
+			return err
+		}
+		tried[key] = true
+	}
+}
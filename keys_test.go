@@ -0,0 +1,209 @@
+package rajaongkir
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// keyRes maps the keys the test API rejects to its answer
+var keyRes = map[string]string{
+	"INVALIDKEY01": `{"rajaongkir":{"status":{"code":400,"description":"Invalid key. API key tidak ditemukan di database RajaOngkir."}}}`,
+	"EXHAUSTED002": `{"rajaongkir":{"status":{"code":400,"description":"Daily limit exceeded."}}}`,
+	"THROTTLED003": `{"rajaongkir":{"status":{"code":429,"description":"Too many requests."}}}`,
+}
+
+type fakeRetryMetrics struct {
+	fakeMetrics
+	retries []string
+}
+
+func (m *fakeRetryMetrics) ObserveRetry(endpoint string) {
+	m.retries = append(m.retries, endpoint)
+}
+
+// setupKeysTest returns a client with a pool of keys and the keys each call was made with
+func setupKeysTest(apiKey string, opts ...Option) (*httptest.Server, *RajaOngkir, func() []string) {
+	mu := sync.Mutex{}
+	used := []string{}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("key")
+		mu.Lock()
+		used = append(used, key)
+		mu.Unlock()
+		if res, ok := keyRes[key]; ok {
+			fmt.Fprint(w, res)
+			return
+		}
+		fmt.Fprint(w, provinceRes)
+	}
+	ts := httptest.NewTLSServer(http.HandlerFunc(handler))
+	hostname := strings.Replace(ts.URL, "https://", "", 1)
+	ro := New(apiKey, hostname, ts.Client(), opts...)
+	return ts, ro, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), used...)
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	tables := []struct {
+		strategy KeyStrategy
+		expected string
+	}{
+		{RoundRobin, "KEYA KEYB KEYC KEYA KEYB KEYC"},
+		{LeastUsed, "KEYA KEYB KEYC KEYA KEYB KEYC"},
+	}
+
+	for _, table := range tables {
+		ts, ro, used := setupKeysTest("KEYA", WithAPIKeys(table.strategy, "KEYB", "KEYC", "KEYA"))
+		for i := 0; i < 6; i++ {
+			if _, err := ro.GetProvince("12"); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+		ts.Close()
+		if got := strings.Join(used(), " "); got != table.expected {
+			t.Errorf("Wrong keys used with strategy %d. Got %s, expected %s", table.strategy, got, table.expected)
+		}
+	}
+
+	p := &keyPool{strategy: LeastUsed, now: time.Now, keys: []*poolKey{{key: "KEYA", requests: 5}, {key: "KEYB", requests: 2}, {key: "KEYC", requests: 3}}}
+	if key, _ := p.pick(nil); key != "KEYB" {
+		t.Errorf("Wrong least used key. Got %s, expected KEYB", key)
+	}
+}
+
+func TestKeyFailover(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	m := &fakeRetryMetrics{}
+	ts, ro, used := setupKeysTest("INVALIDKEY01", WithAPIKeys(RoundRobin, "EXHAUSTED002", "GOODKEY00003"), WithLogger(l), WithMetrics(m))
+	defer ts.Close()
+	now := time.Date(2026, 10, 19, 22, 0, 0, 0, wib)
+	ro.keys.now = func() time.Time { return now }
+
+	province, err := ro.GetProvince("12")
+	if err != nil || province.ProvinceID != "12" {
+		t.Fatalf("Expected the call to fail over to a good key. Got %+v, %v", province, err)
+	}
+	ro.GetProvince("12")
+	if got := strings.Join(used(), " "); got != "INVALIDKEY01 EXHAUSTED002 GOODKEY00003 GOODKEY00003" {
+		t.Errorf("Wrong keys used. Got %s", got)
+	}
+	if !strings.Contains(buf.String(), `"attempt":3`) {
+		t.Errorf("Expected the attempt to be logged. Got %s", buf.String())
+	}
+	if got := strings.Join(m.retries, " "); got != "/province /province" {
+		t.Errorf("Wrong retries observed. Got %q, expected two for /province", got)
+	}
+
+	midnight := time.Date(2026, 10, 20, 0, 0, 0, 0, wib)
+	expected := []KeyStats{
+		{Key: "********EY01", Requests: 1, Rejections: 1, RestingUntil: midnight},
+		{Key: "********D002", Requests: 1, Rejections: 1, RestingUntil: midnight},
+		{Key: "********0003", Requests: 2},
+	}
+	stats := ro.KeyStats()
+	for i := range expected {
+		if !stats[i].RestingUntil.Equal(expected[i].RestingUntil) || stats[i].Key != expected[i].Key ||
+			stats[i].Requests != expected[i].Requests || stats[i].Rejections != expected[i].Rejections {
+			t.Errorf("Wrong stats for key %d. Got %+v, expected %+v", i, stats[i], expected[i])
+		}
+	}
+
+	now = midnight
+	if ro.KeyStats()[0].RestingUntil != (time.Time{}) {
+		t.Errorf("Expected rejected keys back in rotation after midnight WIB")
+	}
+}
+
+func TestKeyFailoverThrottled(t *testing.T) {
+	ts, ro, used := setupKeysTest("THROTTLED003", WithAPIKeys(RoundRobin, "GOODKEY00003"))
+	defer ts.Close()
+	now := time.Date(2026, 10, 19, 22, 0, 0, 0, wib)
+	ro.keys.now = func() time.Time { return now }
+
+	if _, err := ro.GetProvince("12"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if got := ro.KeyStats()[0].RestingUntil; !got.Equal(now.Add(keyBackoff)) {
+		t.Errorf("Wrong rest for a throttled key. Got %s, expected %s", got, now.Add(keyBackoff))
+	}
+	now = now.Add(keyBackoff)
+	ro.GetProvince("12")
+	if got := strings.Join(used(), " "); got != "THROTTLED003 GOODKEY00003 THROTTLED003 GOODKEY00003" {
+		t.Errorf("Expected the throttled key back in rotation after the backoff. Got %s", got)
+	}
+}
+
+func TestKeyRejection(t *testing.T) {
+	tables := []struct {
+		code        int
+		description string
+		expected    rejection
+	}{
+		{400, "Invalid key. API key tidak ditemukan di database RajaOngkir.", refused},
+		{400, "Daily limit exceeded.", refused},
+		{400, "API key quota exceeded", refused},
+		{401, "Unauthorized", refused},
+		{429, "Too many requests.", throttled},
+		{400, "Weight melebihi limit 30000 gram", accepted},
+		{400, "Bad request. Origin tidak valid.", accepted},
+		{200, "OK", accepted},
+		{500, "Internal server error", accepted},
+	}
+
+	for _, table := range tables {
+		if got := keyRejection(&status{Code: table.code, Description: table.description}); got != table.expected {
+			t.Errorf("Wrong rejection for %d %q. Got %d, expected %d", table.code, table.description, got, table.expected)
+		}
+	}
+}
+
+func TestKeyFailoverExhausted(t *testing.T) {
+	ts, ro, used := setupKeysTest("INVALIDKEY01", WithAPIKeys(LeastUsed, "EXHAUSTED002"))
+	defer ts.Close()
+
+	_, err := ro.GetProvince("12")
+	if err == nil || err.Error() != "Daily limit exceeded." {
+		t.Errorf("Expected the last rejection once every key failed. Got %v", err)
+	}
+	if n := len(used()); n != 2 {
+		t.Errorf("Wrong number of calls. Got %d, expected 2", n)
+	}
+	if ro := New("APIKEY12345", "test.com", nil); ro.KeyStats() != nil {
+		t.Errorf("Expected no stats without a key pool")
+	}
+}
+
+func TestNoAPIKey(t *testing.T) {
+	ts, _, used := setupKeysTest("")
+	defer ts.Close()
+	hostname := strings.Replace(ts.URL, "https://", "", 1)
+	clients := []*RajaOngkir{
+		New("", hostname, ts.Client()),
+		New("", hostname, ts.Client(), WithAPIKeys(RoundRobin, "", "")),
+	}
+
+	for i, ro := range clients {
+		if _, err := ro.GetProvince("12"); !errors.Is(err, ErrNoAPIKey) {
+			t.Errorf("Wrong error for client %d. Got %v, expected ErrNoAPIKey", i, err)
+		}
+		if _, err := ro.Verify(context.Background()); !errors.Is(err, ErrNoAPIKey) {
+			t.Errorf("Wrong error verifying client %d. Got %v, expected ErrNoAPIKey", i, err)
+		}
+	}
+	if n := len(used()); n != 0 {
+		t.Errorf("Expected no calls without a key. Got %d", n)
+	}
+}
@@ -0,0 +1,23 @@
+package rajaongkir
+
+import "context"
+
+// Limiter throttles calls to the API.
+// *rate.Limiter from golang.org/x/time/rate satisfies it
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// WithRateLimiter makes every API call wait on l before it is sent
+func WithRateLimiter(l Limiter) Option {
+	return func(r *RajaOngkir) {
+		r.limiter = l
+	}
+}
+
+func (r *RajaOngkir) wait(ctx context.Context) error {
+	if r.limiter == nil {
+		return nil
+	}
+	return r.limiter.Wait(ctx)
+}
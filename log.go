@@ -0,0 +1,80 @@
+package rajaongkir
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// logger holds the slog configuration of a client
+type logger struct {
+	logger       *slog.Logger
+	successLevel slog.Level
+	failureLevel slog.Level
+}
+
+// WithLogger logs every API call to l.
+// Successful calls are logged at slog.LevelDebug and failed ones
+// at slog.LevelError unless overridden with WithLogLevels
+func WithLogger(l *slog.Logger) Option {
+	return func(r *RajaOngkir) {
+		if l == nil {
+			r.logger = nil
+			return
+		}
+		if r.logger == nil {
+			r.logger = &logger{successLevel: slog.LevelDebug, failureLevel: slog.LevelError}
+		}
+		r.logger.logger = l
+	}
+}
+
+// WithLogLevels sets the levels used for logging successful and failed calls.
+// It has no effect unless a logger is configured with WithLogger
+func WithLogLevels(success, failure slog.Level) Option {
+	return func(r *RajaOngkir) {
+		if r.logger == nil {
+			r.logger = &logger{}
+		}
+		r.logger.successLevel = success
+		r.logger.failureLevel = failure
+	}
+}
+
+// redactKey hides all but the last four characters of an API key
+func redactKey(key string) string {
+	if len(key) <= 4 {
+		return strings.Repeat("*", len(key))
+	}
+	return strings.Repeat("*", len(key)-4) + key[len(key)-4:]
+}
+
+func (r *RajaOngkir) logRequest(res *callResult) {
+	if r.logger == nil || r.logger.logger == nil {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("method", res.method),
+		slog.String("endpoint", res.endpoint),
+		slog.Int("status_code", res.statusCode),
+		slog.Duration("duration", res.duration),
+		slog.String("key", redactKey(res.key)),
+	}
+	if res.attempt > 1 {
+		attrs = append(attrs, slog.Int("attempt", res.attempt))
+	}
+	if res.status != nil {
+		attrs = append(attrs,
+			slog.Int("rajaongkir_status", res.status.Code),
+			slog.String("rajaongkir_description", res.status.Description),
+		)
+	}
+	if res.err != nil {
+		attrs = append(attrs, slog.String("error", res.err.Error()))
+	}
+	level := r.logger.successLevel
+	if res.failed() {
+		level = r.logger.failureLevel
+	}
+	r.logger.logger.LogAttrs(context.Background(), level, "rajaongkir request", attrs...)
+}
@@ -0,0 +1,84 @@
+package rajaongkir
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestRedactKey(t *testing.T) {
+	tables := []struct {
+		key      string
+		expected string
+	}{
+		{"APIKEY12345", "*******2345"},
+		{"1234", "****"},
+		{"", ""},
+	}
+
+	for _, table := range tables {
+		result := redactKey(table.key)
+		if result != table.expected {
+			t.Errorf("Wrong redacted key. Got %s, expected %s", result, table.expected)
+		}
+	}
+}
+
+func TestLogRequest(t *testing.T) {
+	tables := []struct {
+		fakeResponse     string
+		call             func(ro *RajaOngkir)
+		expectedLevel    string
+		expectedEndpoint string
+		expectedStatus   float64
+	}{
+		{provinceRes, func(ro *RajaOngkir) { ro.GetProvince("12") }, "DEBUG", "/province?id=12", 200},
+		{provincesRes, func(ro *RajaOngkir) { ro.GetProvinces() }, "ERROR", "/province", 400},
+		{costRes, func(ro *RajaOngkir) { ro.GetCost("501", "114", 1700, "jne") }, "DEBUG", "/cost", 200},
+	}
+
+	for _, table := range tables {
+		buf := &bytes.Buffer{}
+		l := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		ts, ro, _ := setupTest(table.fakeResponse, WithLogger(l))
+		table.call(ro)
+		ts.Close()
+
+		if strings.Contains(buf.String(), "APIKEY12345") {
+			t.Errorf("API key leaked into log output: %s", buf.String())
+		}
+		entry := map[string]interface{}{}
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("Invalid log output %q: %s", buf.String(), err)
+		}
+		if entry["level"] != table.expectedLevel {
+			t.Errorf("Wrong level. Got %v, expected %s", entry["level"], table.expectedLevel)
+		}
+		if entry["endpoint"] != table.expectedEndpoint {
+			t.Errorf("Wrong endpoint. Got %v, expected %s", entry["endpoint"], table.expectedEndpoint)
+		}
+		if entry["rajaongkir_status"] != table.expectedStatus {
+			t.Errorf("Wrong status. Got %v, expected %v", entry["rajaongkir_status"], table.expectedStatus)
+		}
+		if entry["status_code"] != float64(200) {
+			t.Errorf("Wrong status code. Got %v, expected 200", entry["status_code"])
+		}
+		if entry["key"] != "*******2345" {
+			t.Errorf("Wrong key. Got %v, expected redacted key", entry["key"])
+		}
+	}
+}
+
+func TestLogLevels(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := slog.New(slog.NewTextHandler(buf, nil))
+	ts, ro, _ := setupTest(provinceRes, WithLogLevels(slog.LevelInfo, slog.LevelWarn), WithLogger(l))
+	defer ts.Close()
+	ro.GetProvince("12")
+
+	if !strings.Contains(buf.String(), "level=INFO") {
+		t.Errorf("Expected call to be logged at INFO. Got %s", buf.String())
+	}
+}
@@ -0,0 +1,66 @@
+package rajaongkir
+
+import (
+	"strings"
+	"time"
+)
+
+// Metrics receives measurements about the calls made by a client.
+// See the rajaongkirprom package for a Prometheus implementation
+type Metrics interface {
+	// ObserveRequest records a finished call to endpoint, without its query string.
+	// status is the RajaOngkir status code, or 0 if no response was decoded
+	ObserveRequest(endpoint string, status int, duration time.Duration, err error)
+}
+
+// HedgeMetrics may be implemented by a Metrics to learn how hedged calls went
+type HedgeMetrics interface {
+	// ObserveHedge records a hedge sent to endpoint, and whether its response was the one used
+	ObserveHedge(endpoint string, won bool)
+}
+
+// RetryMetrics may be implemented by a Metrics to count the calls retried
+// with another key of a pool created WithAPIKeys
+type RetryMetrics interface {
+	// ObserveRetry records a call to endpoint retried after RajaOngkir rejected the key
+	ObserveRetry(endpoint string)
+}
+
+// CacheMetrics is told about the lookups of the caches in front of a client,
+// see rajaongkirproxy.WithMetrics and rajaongkirgrpc.WithMetrics
+type CacheMetrics interface {
+	// ObserveCache records a lookup of endpoint and whether it was served from the cache
+	ObserveCache(endpoint string, hit bool)
+}
+
+// WithMetrics reports every API call to m
+func WithMetrics(m Metrics) Option {
+	return func(r *RajaOngkir) {
+		r.metrics = m
+	}
+}
+
+func (r *RajaOngkir) observeRequest(res *callResult) {
+	if r.metrics == nil {
+		return
+	}
+	status := 0
+	if res.status != nil {
+		status = res.status.Code
+	}
+	r.metrics.ObserveRequest(res.path(), status, res.duration, res.err)
+}
+
+func (r *RajaOngkir) observeHedge(endpoint string, won bool) {
+	if m, ok := r.metrics.(HedgeMetrics); ok {
+		path, _, _ := strings.Cut(endpoint, "?")
+		m.ObserveHedge(path, won)
+	}
+}
+
+func (r *RajaOngkir) observeRetry(endpoint string) {
+	if m, ok := r.metrics.(RetryMetrics); ok {
+		path, _, _ := strings.Cut(endpoint, "?")
+		m.ObserveRetry(path)
+	}
+}
@@ -0,0 +1,56 @@
+package rajaongkir
+
+import (
+	"testing"
+	"time"
+)
+
+type observation struct {
+	endpoint string
+	status   int
+	err      error
+}
+
+type fakeMetrics struct {
+	observations []observation
+}
+
+func (m *fakeMetrics) ObserveRequest(endpoint string, status int, duration time.Duration, err error) {
+	m.observations = append(m.observations, observation{endpoint, status, err})
+}
+
+func TestObserveRequest(t *testing.T) {
+	tables := []struct {
+		fakeResponse     string
+		call             func(ro *RajaOngkir)
+		expectedEndpoint string
+		expectedStatus   int
+		isErr            bool
+	}{
+		{provinceRes, func(ro *RajaOngkir) { ro.GetProvince("12") }, "/province", 200, false},
+		{provincesRes, func(ro *RajaOngkir) { ro.GetProvinces() }, "/province", 400, false},
+		{cityRes, func(ro *RajaOngkir) { ro.GetCity("5", "39") }, "/city", 200, false},
+		{"<html></html>", func(ro *RajaOngkir) { ro.GetCities() }, "/city", 0, true},
+	}
+
+	for _, table := range tables {
+		m := &fakeMetrics{}
+		ts, ro, _ := setupTest(table.fakeResponse, WithMetrics(m))
+		table.call(ro)
+		ts.Close()
+
+		if len(m.observations) != 1 {
+			t.Fatalf("Wrong number of observations. Got %d, expected 1", len(m.observations))
+		}
+		o := m.observations[0]
+		if o.endpoint != table.expectedEndpoint {
+			t.Errorf("Wrong endpoint. Got %s, expected %s", o.endpoint, table.expectedEndpoint)
+		}
+		if o.status != table.expectedStatus {
+			t.Errorf("Wrong status. Got %d, expected %d", o.status, table.expectedStatus)
+		}
+		if (o.err != nil) != table.isErr {
+			t.Errorf("Error mismatch. Got %s, expected %v", o.err, table.isErr)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package rajaongkir
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Ranking decides which origin is best for a service
+type Ranking int
+
+// List of rankings
+const (
+	// ByCost prefers the cheapest origin, then the fastest
+	ByCost Ranking = iota
+	// ByETD prefers the origin with the shortest delivery estimate, then the cheapest
+	ByETD
+)
+
+// OriginQuote is the price of a courier service from one origin
+type OriginQuote struct {
+	Origin      string
+	Courier     string
+	Service     string
+	Description string
+	Cost        int
+	ETD         string
+	// Estimated is set if the cost came from a Fallback
+	Estimated bool
+}
+
+// OriginFailure is a courier that could not be quoted from an origin
+type OriginFailure struct {
+	Origin  string
+	Courier string
+	Err     error
+}
+
+// OriginComparison holds the quotes from every candidate origin.
+// Quotes is the full matrix in the order of the origins and couriers asked for,
+// Best holds the best origin for each courier service
+type OriginComparison struct {
+	Quotes []OriginQuote
+	Best   []OriginQuote
+	Failed []OriginFailure
+}
+
+// CompareOrigins quotes p to destination from each of origins with each courier,
+// as a batch, and picks the best origin for every courier service according to by.
+// An error is returned if the arguments are invalid or no origin could be quoted
+func (r *RajaOngkir) CompareOrigins(ctx context.Context, origins []string, destination string, p Parcel, couriers []string, by Ranking) (OriginComparison, error) {
+	if len(origins) == 0 {
+		return OriginComparison{}, errors.New("no origins to compare")
+	}
+	if len(couriers) == 0 {
+		return OriginComparison{}, errors.New("no couriers to compare")
+	}
+	weight := p.ChargeableWeight()
+	if err := Weight(weight).Validate(r.tier); err != nil {
+		return OriginComparison{}, err
+	}
+
+	reqs := make([]CostRequest, 0, len(origins)*len(couriers))
+	for _, origin := range origins {
+		for _, courier := range couriers {
+			reqs = append(reqs, CostRequest{Origin: origin, Destination: destination, Weight: weight, Courier: courier})
+		}
+	}
+	results := r.GetCostsBatch(ctx, reqs)
+
+	cmp := OriginComparison{Quotes: []OriginQuote{}, Best: []OriginQuote{}, Failed: []OriginFailure{}}
+	best := map[string]int{}
+	for _, res := range results {
+		if res.Err != nil {
+			cmp.Failed = append(cmp.Failed, OriginFailure{Origin: res.Request.Origin, Courier: res.Request.Courier, Err: res.Err})
+			continue
+		}
+		for _, c := range res.Costs {
+			if len(c.Cost) == 0 {
+				continue
+			}
+			q := OriginQuote{
+				Origin:      res.Request.Origin,
+				Courier:     res.Request.Courier,
+				Service:     c.Service,
+				Description: c.Description,
+				Cost:        c.Cost[0].Value,
+				ETD:         c.Cost[0].ETD,
+				Estimated:   c.Estimated,
+			}
+			cmp.Quotes = append(cmp.Quotes, q)
+			key := q.Courier + ":" + q.Service
+			if i, ok := best[key]; !ok {
+				best[key] = len(cmp.Best)
+				cmp.Best = append(cmp.Best, q)
+			} else if better(q, cmp.Best[i], by) {
+				cmp.Best[i] = q
+			}
+		}
+	}
+	if allFailed(results) {
+		return cmp, results[0].Err
+	}
+	return cmp, nil
+}
+
+// better reports whether a beats b according to by
+func better(a, b OriginQuote, by Ranking) bool {
+	aMin, aMax := etdDays(a.ETD)
+	bMin, bMax := etdDays(b.ETD)
+	if by == ByETD && (aMax != bMax || aMin != bMin) {
+		return aMax < bMax || (aMax == bMax && aMin < bMin)
+	}
+	if a.Cost != b.Cost {
+		return a.Cost < b.Cost
+	}
+	return aMax < bMax || (aMax == bMax && aMin < bMin)
+}
+
+// ParseETD parses a delivery estimate such as "2-3", "1" or "2-4 HARI" into days.
+// ok is false if etd has no number in it
+func ParseETD(etd string) (min, max int, ok bool) {
+	days := []int{}
+	for _, f := range strings.FieldsFunc(etd, func(r rune) bool { return !unicode.IsDigit(r) }) {
+		if n, err := strconv.Atoi(f); err == nil {
+			days = append(days, n)
+		}
+	}
+	if len(days) == 0 {
+		return 0, 0, false
+	}
+	return days[0], days[len(days)-1], true
+}
+
+// etdDays is like ParseETD but sorts estimates without a number after every other
+func etdDays(etd string) (int, int) {
+	min, max, ok := ParseETD(etd)
+	if !ok {
+		return math.MaxInt, math.MaxInt
+	}
+	return min, max
+}
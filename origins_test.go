@@ -0,0 +1,97 @@
+package rajaongkir
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// originRes maps an origin to the OKE and YES services it is quoted
+var originRes = map[string]string{
+	"501": `{"service":"OKE","description":"Ongkos Kirim Ekonomis","cost":[{"value":38000,"etd":"4-5","note":""}]},
+		{"service":"YES","description":"Yakin Esok Sampai","cost":[{"value":98000,"etd":"1-1","note":""}]}`,
+	"39": `{"service":"OKE","description":"Ongkos Kirim Ekonomis","cost":[{"value":30000,"etd":"5-7","note":""}]},
+		{"service":"YES","description":"Yakin Esok Sampai","cost":[{"value":104000,"etd":"1-1","note":""}]}`,
+}
+
+func setupOriginsTest() (*httptest.Server, *RajaOngkir) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		costs, ok := originRes[r.PostForm.Get("origin")]
+		if !ok {
+			fmt.Fprint(w, `{"rajaongkir":{"status":{"code":400,"description":"Bad request. Origin tidak valid."}}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"rajaongkir":{"status":{"code":200,"description":"OK"},"results":[{"code":"jne","costs":[%s]}]}}`, costs)
+	}
+	ts := httptest.NewTLSServer(http.HandlerFunc(handler))
+	hostname := strings.Replace(ts.URL, "https://", "", 1)
+	return ts, New("APIKEY12345", hostname, ts.Client())
+}
+
+func TestCompareOrigins(t *testing.T) {
+	ts, ro := setupOriginsTest()
+	defer ts.Close()
+
+	tables := []struct {
+		by       Ranking
+		expected []string
+	}{
+		{ByCost, []string{"OKE from 39", "YES from 501"}},
+		{ByETD, []string{"OKE from 501", "YES from 501"}},
+	}
+
+	for _, table := range tables {
+		cmp, err := ro.CompareOrigins(context.Background(), []string{"501", "39", "9999"}, "114", Parcel{Weight: 1700}, []string{"jne"}, table.by)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(cmp.Quotes) != 4 {
+			t.Errorf("Wrong number of quotes. Got %d, expected 4", len(cmp.Quotes))
+		}
+		best := []string{}
+		for _, q := range cmp.Best {
+			best = append(best, q.Service+" from "+q.Origin)
+		}
+		if !reflect.DeepEqual(best, table.expected) {
+			t.Errorf("Wrong best origins for ranking %d. Got %v, expected %v", table.by, best, table.expected)
+		}
+		if len(cmp.Failed) != 1 || cmp.Failed[0].Origin != "9999" || cmp.Failed[0].Err == nil {
+			t.Errorf("Wrong failures. Got %+v, expected origin 9999", cmp.Failed)
+		}
+	}
+
+	_, err := ro.CompareOrigins(context.Background(), []string{"9999"}, "114", Parcel{Weight: 1700}, []string{"jne"}, ByCost)
+	if err == nil || err.Error() != "Bad request. Origin tidak valid." {
+		t.Errorf("Expected the API error when every origin fails. Got %v", err)
+	}
+}
+
+func TestParseETD(t *testing.T) {
+	tables := []struct {
+		etd      string
+		min, max int
+	}{
+		{"2-3", 2, 3},
+		{"1-1", 1, 1},
+		{"3", 3, 3},
+		{"2-4 HARI", 2, 4},
+	}
+
+	for _, table := range tables {
+		min, max, ok := ParseETD(table.etd)
+		if !ok || min != table.min || max != table.max {
+			t.Errorf("Wrong days for %q. Got %d-%d, expected %d-%d", table.etd, min, max, table.min, table.max)
+		}
+	}
+	if _, _, ok := ParseETD("HARI"); ok {
+		t.Errorf("Expected an estimate without days not to parse")
+	}
+	if _, max := etdDays(""); max <= 1000 {
+		t.Errorf("Expected an empty estimate to sort last. Got %d", max)
+	}
+}
@@ -0,0 +1,84 @@
+package rajaongkir
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// DefaultMaxParcelWeight is the heaviest parcel in grams RajaOngkir quotes on the Starter and Basic tiers
+const DefaultMaxParcelWeight = 30000
+
+// ErrItemTooHeavy is returned when a single item weighs more than a parcel may
+var ErrItemTooHeavy = errors.New("item is heavier than the maximum parcel weight")
+
+// Item is a line of an order to be packed.
+// Weight is in grams and dimensions in centimetres, both per unit
+type Item struct {
+	Weight   int
+	Length   int
+	Width    int
+	Height   int
+	Quantity int
+}
+
+// PackItems splits items into as few parcels of at most maxWeight grams as it can,
+// placing the heaviest units first into the first parcel with room (first-fit decreasing).
+// Units are weighed at their chargeable weight, so the returned parcels
+// carry the weight couriers will charge for and no dimensions.
+// A maxWeight of 0 or less uses DefaultMaxParcelWeight
+func PackItems(items []Item, maxWeight int) ([]Parcel, error) {
+	if maxWeight <= 0 {
+		maxWeight = DefaultMaxParcelWeight
+	}
+	units := []int{}
+	for i, item := range items {
+		if item.Quantity < 0 {
+			return nil, fmt.Errorf("item %d: quantity must not be negative", i)
+		}
+		p := Parcel{Weight: item.Weight, Length: item.Length, Width: item.Width, Height: item.Height}
+		w := p.ChargeableWeight()
+		if w <= 0 {
+			return nil, fmt.Errorf("item %d: weight must be positive", i)
+		}
+		if w > maxWeight {
+			return nil, fmt.Errorf("item %d: %w (%dg > %dg)", i, ErrItemTooHeavy, w, maxWeight)
+		}
+		for n := 0; n < item.Quantity; n++ {
+			units = append(units, w)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(units)))
+
+	parcels := []Parcel{}
+	for _, w := range units {
+		placed := false
+		for i := range parcels {
+			if parcels[i].Weight+w <= maxWeight {
+				parcels[i].Weight += w
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			parcels = append(parcels, Parcel{Weight: w})
+		}
+	}
+	return parcels, nil
+}
+
+// QuoteItems packs items into parcels of at most maxWeight grams with PackItems
+// and quotes them with courier as a Shipment
+func (r *RajaOngkir) QuoteItems(ctx context.Context, origin, destination, courier string, items []Item, maxWeight int) (ShipmentQuote, error) {
+	parcels, err := PackItems(items, maxWeight)
+	if err != nil {
+		return ShipmentQuote{}, err
+	}
+	return r.QuoteShipment(ctx, Shipment{
+		Origin:      origin,
+		Destination: destination,
+		Couriers:    []string{courier},
+		Parcels:     parcels,
+	})
+}
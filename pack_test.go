@@ -0,0 +1,69 @@
+package rajaongkir
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPackItems(t *testing.T) {
+	tables := []struct {
+		items     []Item
+		maxWeight int
+		expected  []int
+	}{
+		{[]Item{{Weight: 1000, Quantity: 3}}, 5000, []int{3000}},
+		{[]Item{{Weight: 4000, Quantity: 2}, {Weight: 1000, Quantity: 3}}, 5000, []int{5000, 5000, 1000}},
+		{[]Item{{Weight: 2000, Quantity: 1}, {Weight: 3000, Quantity: 1}, {Weight: 3000, Quantity: 1}, {Weight: 2000, Quantity: 1}}, 5000, []int{5000, 5000}},
+		{[]Item{{Weight: 500, Length: 40, Width: 40, Height: 40, Quantity: 2}}, 0, []int{21332}},
+		{[]Item{{Weight: 500, Length: 40, Width: 40, Height: 40, Quantity: 2}}, 20000, []int{10666, 10666}},
+		{[]Item{{Weight: 1000, Quantity: 0}}, 5000, []int{}},
+	}
+
+	for _, table := range tables {
+		parcels, err := PackItems(table.items, table.maxWeight)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		weights := []int{}
+		for _, p := range parcels {
+			weights = append(weights, p.Weight)
+		}
+		if !reflect.DeepEqual(weights, table.expected) {
+			t.Errorf("Wrong parcels for %+v. Got %v, expected %v", table.items, weights, table.expected)
+		}
+	}
+}
+
+func TestPackItemsErrors(t *testing.T) {
+	_, err := PackItems([]Item{{Weight: 1000, Quantity: 1}, {Weight: 6000, Quantity: 1}}, 5000)
+	if !errors.Is(err, ErrItemTooHeavy) {
+		t.Errorf("Expected ErrItemTooHeavy. Got %v", err)
+	}
+	_, err = PackItems([]Item{{Quantity: 1}}, 5000)
+	if err == nil {
+		t.Errorf("Expected an error for an item without weight")
+	}
+}
+
+func TestQuoteItems(t *testing.T) {
+	ts, ro := setupShipmentTest()
+	defer ts.Close()
+
+	items := []Item{{Weight: 4000, Quantity: 2}, {Weight: 1000, Quantity: 3}}
+	quote, err := ro.QuoteItems(context.Background(), "501", "114", "jne", items, 5000)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(quote.Parcels) != 3 {
+		t.Fatalf("Wrong number of parcels. Got %d, expected 3", len(quote.Parcels))
+	}
+	expected := []ServiceQuote{
+		{Courier: "jne", Service: "OKE", Description: "Ongkos Kirim Ekonomis", ETD: "4-5", Total: 95000 + 95000 + 19000, Costs: []int{95000, 95000, 19000}},
+		{Courier: "jne", Service: "YES", Description: "Yakin Esok Sampai", ETD: "1-1", Total: 245000 + 245000 + 49000, Costs: []int{245000, 245000, 49000}},
+	}
+	if !reflect.DeepEqual(quote.Services, expected) {
+		t.Errorf("Wrong services. Got %+v, expected %+v", quote.Services, expected)
+	}
+}
@@ -0,0 +1,64 @@
+package rajaongkir
+
+import "strings"
+
+// minPostalPrefix is the shortest prefix used to match postal codes.
+// The first three digits of an Indonesian postal code narrow it down to a regency or city
+const minPostalPrefix = 3
+
+// PostalIndex resolves postal codes to cities and subdistricts
+type PostalIndex struct {
+	byPrefix     map[string][]City
+	subdistricts map[CityID][]Subdistrict
+}
+
+// NewPostalIndex indexes cities, e.g. as returned by GetCities, by postal code.
+// subdistricts, e.g. as returned by GetSubdistricts on the Pro tier,
+// are indexed under the postal code of their city
+func NewPostalIndex(cities []City, subdistricts ...Subdistrict) *PostalIndex {
+	p := &PostalIndex{byPrefix: map[string][]City{}, subdistricts: map[CityID][]Subdistrict{}}
+	for _, c := range cities {
+		code := strings.TrimSpace(c.PostalCode)
+		for l := minPostalPrefix; l <= len(code); l++ {
+			p.byPrefix[code[:l]] = append(p.byPrefix[code[:l]], c)
+		}
+	}
+	for _, sd := range subdistricts {
+		p.subdistricts[sd.CityID] = append(p.subdistricts[sd.CityID], sd)
+	}
+	return p
+}
+
+// CityByPostalCode returns the cities matching code, which may be a full postal code
+// or a prefix of at least three digits.
+// Cities whose postal code matches exactly are preferred, otherwise those sharing
+// the longest prefix with code are returned.
+// ambiguous is true when more than one city is returned
+func (p *PostalIndex) CityByPostalCode(code string) (candidates []City, ambiguous bool) {
+	code = strings.TrimSpace(code)
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return []City{}, false
+		}
+	}
+	for l := len(code); l >= minPostalPrefix; l-- {
+		if cities, ok := p.byPrefix[code[:l]]; ok {
+			candidates = append([]City(nil), cities...)
+			return candidates, len(candidates) > 1
+		}
+	}
+	return []City{}, false
+}
+
+// SubdistrictByPostalCode returns the subdistricts of the cities matching code,
+// see CityByPostalCode. RajaOngkir has no postal code per subdistrict, so every
+// subdistrict of a matching city is a candidate.
+// ambiguous is true when more than one subdistrict is returned
+func (p *PostalIndex) SubdistrictByPostalCode(code string) (candidates []Subdistrict, ambiguous bool) {
+	candidates = []Subdistrict{}
+	cities, _ := p.CityByPostalCode(code)
+	for _, c := range cities {
+		candidates = append(candidates, p.subdistricts[c.CityID]...)
+	}
+	return candidates, len(candidates) > 1
+}
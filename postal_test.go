@@ -0,0 +1,106 @@
+package rajaongkir
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCityByPostalCode(t *testing.T) {
+	cities := []City{
+		{CityID: "39", ProvinceID: "5", Type: CityTypeKabupaten, CityName: "Bantul", PostalCode: "55715"},
+		{CityID: "419", ProvinceID: "5", Type: CityTypeKabupaten, CityName: "Sleman", PostalCode: "55513"},
+		{CityID: "501", ProvinceID: "5", Type: CityTypeKota, CityName: "Yogyakarta", PostalCode: "55111"},
+		{CityID: "22", ProvinceID: "9", Type: CityTypeKabupaten, CityName: "Bandung", PostalCode: "40311"},
+		{CityID: "23", ProvinceID: "9", Type: CityTypeKota, CityName: "Bandung", PostalCode: "40111"},
+		{CityID: "107", ProvinceID: "9", Type: CityTypeKota, CityName: "Cimahi", PostalCode: "40512"},
+	}
+	p := NewPostalIndex(cities)
+
+	tables := []struct {
+		code              string
+		expectedIDs       []string
+		expectedAmbiguous bool
+	}{
+		{"55715", []string{"39"}, false},
+		{"55711", []string{"39"}, false},
+		{"55165", []string{"501"}, false},
+		{"551", []string{"501"}, false},
+		{"555", []string{"419"}, false},
+		{"401", []string{"23"}, false},
+		{"40", []string{}, false},
+		{"40999", []string{}, false},
+		{"4x111", []string{}, false},
+		{"55999", []string{}, false},
+		{" 40311 ", []string{"22"}, false},
+	}
+
+	for _, table := range tables {
+		candidates, ambiguous := p.CityByPostalCode(table.code)
+		ids := []string{}
+		for _, c := range candidates {
+			ids = append(ids, string(c.CityID))
+		}
+		if len(ids) != len(table.expectedIDs) || ambiguous != table.expectedAmbiguous {
+			t.Errorf("Wrong match for %q. Got %v/%v, expected %v/%v", table.code, ids, ambiguous, table.expectedIDs, table.expectedAmbiguous)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != table.expectedIDs[i] {
+				t.Errorf("Wrong match for %q. Got %v, expected %v", table.code, ids, table.expectedIDs)
+				break
+			}
+		}
+	}
+}
+
+func TestSubdistrictByPostalCode(t *testing.T) {
+	cities := []City{
+		{CityID: "39", ProvinceID: "5", Type: CityTypeKabupaten, CityName: "Bantul", PostalCode: "55715"},
+		{CityID: "501", ProvinceID: "5", Type: CityTypeKota, CityName: "Yogyakarta", PostalCode: "55111"},
+		{CityID: "419", ProvinceID: "5", Type: CityTypeKabupaten, CityName: "Sleman", PostalCode: "55513"},
+	}
+	p := NewPostalIndex(cities,
+		Subdistrict{SubdistrictID: "537", CityID: "39", SubdistrictName: "Bambang Lipuro"},
+		Subdistrict{SubdistrictID: "538", CityID: "39", SubdistrictName: "Banguntapan"},
+		Subdistrict{SubdistrictID: "6981", CityID: "501", SubdistrictName: "Gondokusuman"},
+	)
+
+	tables := []struct {
+		code              string
+		expectedIDs       []string
+		expectedAmbiguous bool
+	}{
+		{"55165", []string{"6981"}, false},
+		{"55715", []string{"537", "538"}, true},
+		{"55513", []string{}, false},
+		{"99999", []string{}, false},
+	}
+
+	for _, table := range tables {
+		candidates, ambiguous := p.SubdistrictByPostalCode(table.code)
+		ids := []string{}
+		for _, sd := range candidates {
+			ids = append(ids, sd.SubdistrictID)
+		}
+		if !reflect.DeepEqual(ids, table.expectedIDs) || ambiguous != table.expectedAmbiguous {
+			t.Errorf("Wrong match for %q. Got %v/%v, expected %v/%v", table.code, ids, ambiguous, table.expectedIDs, table.expectedAmbiguous)
+		}
+	}
+}
+
+func TestCityByPostalCodeAmbiguous(t *testing.T) {
+	p := NewPostalIndex([]City{
+		{CityID: "22", CityName: "Bandung", PostalCode: "40311"},
+		{CityID: "23", CityName: "Bandung", PostalCode: "40111"},
+		{CityID: "24", CityName: "Bandung Barat", PostalCode: "40391"},
+	})
+
+	candidates, ambiguous := p.CityByPostalCode("40355")
+	if len(candidates) != 2 || !ambiguous {
+		t.Errorf("Expected both cities sharing 403 to be ambiguous. Got %v/%v", candidates, ambiguous)
+	}
+	candidates, ambiguous = p.CityByPostalCode("40311")
+	if len(candidates) != 1 || ambiguous {
+		t.Errorf("Expected an exact match. Got %v/%v", candidates, ambiguous)
+	}
+}
@@ -3,7 +3,7 @@
 package rajaongkir
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -19,11 +19,25 @@ const (
 
 // RajaOngkir stores the credentials for accessing the API
 type RajaOngkir struct {
-	apiKey  string
-	baseURL string
-	client  *http.Client
+	apiKey           string
+	baseURL          string
+	client           *http.Client
+	logger           *logger
+	metrics          Metrics
+	tracer           Tracer
+	maxResponseSize  int64
+	limiter          Limiter
+	batchConcurrency int
+	tier             Tier
+	fallbacks        []Fallback
+	breaker          *breaker
+	hedgeDelay       time.Duration
+	keys             *keyPool
 }
 
+// Option configures optional behaviour of the client
+type Option func(*RajaOngkir)
+
 type query map[string]interface{}
 
 type status struct {
@@ -46,22 +60,30 @@ type Cost struct {
 		ETD   string `json:"etd"`
 		Note  string `json:"note"`
 	} `json:"cost"`
+	// Estimated is set on costs served by a Fallback instead of the API
+	Estimated bool `json:"estimated,omitempty"`
 }
 
 // Province stores the details of a province
 type Province struct {
-	ProvinceID string `json:"province_id"`
-	Province   string `json:"province"`
+	ProvinceID ProvinceID `json:"province_id"`
+	Province   string     `json:"province"`
 }
 
 // City stores the details of a city
 type City struct {
-	CityID     string `json:"city_id"`
-	ProvinceID string `json:"province_id"`
-	Province   string `json:"province"`
-	Type       string `json:"type"`
-	CityName   string `json:"city_name"`
-	PostalCode string `json:"postal_code"`
+	CityID     CityID     `json:"city_id"`
+	ProvinceID ProvinceID `json:"province_id"`
+	Province   string     `json:"province"`
+	Type       CityType   `json:"type"`
+	CityName   string     `json:"city_name"`
+	PostalCode string     `json:"postal_code"`
+}
+
+// responder is implemented by every response envelope
+// so the status can be inspected without knowing the concrete type
+type responder interface {
+	responseStatus() *status
 }
 
 type provinceResponse struct {
@@ -105,27 +127,60 @@ type costResponse struct {
 	} `json:"rajaongkir"`
 }
 
+func (re *provinceResponse) responseStatus() *status  { return &re.Rajaongkir.Status }
+func (re *provincesResponse) responseStatus() *status { return &re.Rajaongkir.Status }
+func (re *cityResponse) responseStatus() *status      { return &re.Rajaongkir.Status }
+func (re *citiesResponse) responseStatus() *status    { return &re.Rajaongkir.Status }
+func (re *costResponse) responseStatus() *status      { return &re.Rajaongkir.Status }
+
 // New initializes a new RajaOngkir struct
 // with a default client configured if none is specified
-func New(apiKey, baseURL string, client *http.Client) *RajaOngkir {
+func New(apiKey, baseURL string, client *http.Client, opts ...Option) *RajaOngkir {
 	if client == nil {
 		client = &http.Client{Timeout: defaultClientTimeout}
 	}
-	r := &RajaOngkir{apiKey, baseURL, client}
+	r := &RajaOngkir{apiKey: apiKey, baseURL: baseURL, client: client}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.initFallbacks()
 	return r
 }
 
+// StatusError is returned when RajaOngkir answers with a non 2xx status in the response body
+type StatusError struct {
+	Code        int
+	Description string
+}
+
+func (e *StatusError) Error() string {
+	return e.Description
+}
+
+// KeyRejected reports whether RajaOngkir refused the API key itself,
+// because it is invalid or has used up its quota, rather than the request
+func (e *StatusError) KeyRejected() bool {
+	return rejectsKey(&status{Code: e.Code, Description: e.Description})
+}
+
 func checkStatus(status *status) error {
 	if status.Code >= 200 && status.Code < 300 {
 		return nil
 	}
-	return errors.New(status.Description)
+	return &StatusError{Code: status.Code, Description: status.Description}
 }
 
 // GetProvinces fetches the list of provinces
 func (r *RajaOngkir) GetProvinces() ([]Province, error) {
+	return r.GetProvincesContext(context.Background())
+}
+
+// GetProvincesContext is like GetProvinces but carries ctx
+func (r *RajaOngkir) GetProvincesContext(ctx context.Context) (provinces []Province, err error) {
+	ctx, span := r.startSpan(ctx, "GetProvinces")
+	defer func() { finishSpan(span, err) }()
 	re := &provincesResponse{}
-	err := r.sendRequest(http.MethodGet, provinceEndpoint, "", re)
+	err = r.sendRequest(ctx, http.MethodGet, provinceEndpoint, "", re)
 	if err != nil {
 		return nil, err
 	}
@@ -133,16 +188,24 @@ func (r *RajaOngkir) GetProvinces() ([]Province, error) {
 	if err != nil {
 		return nil, err
 	}
-	provinces := re.Rajaongkir.Results
+	provinces = re.Rajaongkir.Results
 	return provinces, nil
 }
 
 // GetProvince fetches a specific province
 // matching a given ID
 func (r *RajaOngkir) GetProvince(id string) (Province, error) {
+	return r.GetProvinceContext(context.Background(), id)
+}
+
+// GetProvinceContext is like GetProvince but carries ctx
+func (r *RajaOngkir) GetProvinceContext(ctx context.Context, id string) (province Province, err error) {
+	ctx, span := r.startSpan(ctx, "GetProvince")
+	defer func() { finishSpan(span, err) }()
+	span.SetAttribute("rajaongkir.province_id", id)
 	re := &provinceResponse{}
 	endpoint := fmt.Sprintf("%s?id=%s", provinceEndpoint, id)
-	err := r.sendRequest(http.MethodGet, endpoint, "", re)
+	err = r.sendRequest(ctx, http.MethodGet, endpoint, "", re)
 	if err != nil {
 		return Province{}, err
 	}
@@ -150,58 +213,124 @@ func (r *RajaOngkir) GetProvince(id string) (Province, error) {
 	if err != nil {
 		return Province{}, err
 	}
-	province := re.Rajaongkir.Results
+	province = re.Rajaongkir.Results
 	return province, nil
 }
 
 // GetCities fetches the list of cities
 func (r *RajaOngkir) GetCities() ([]City, error) {
+	return r.GetCitiesContext(context.Background())
+}
+
+// GetCitiesContext is like GetCities but carries ctx
+func (r *RajaOngkir) GetCitiesContext(ctx context.Context) (cities []City, err error) {
+	ctx, span := r.startSpan(ctx, "GetCities")
+	defer func() { finishSpan(span, err) }()
 	re := &citiesResponse{}
-	err := r.sendRequest(http.MethodGet, cityEndpoint, "", re)
+	err = r.sendRequest(ctx, http.MethodGet, cityEndpoint, "", re)
 	if err != nil {
 		return []City{}, err
 	}
-	cities := re.Rajaongkir.Results
+	err = checkStatus(&re.Rajaongkir.Status)
+	if err != nil {
+		return []City{}, err
+	}
+	cities = re.Rajaongkir.Results
 	return cities, nil
 }
 
 // GetCitiesInProvince fetches the list of cities in provinceID
 func (r *RajaOngkir) GetCitiesInProvince(provinceID string) ([]City, error) {
+	return r.GetCitiesInProvinceContext(context.Background(), provinceID)
+}
+
+// GetCitiesInProvinceContext is like GetCitiesInProvince but carries ctx
+func (r *RajaOngkir) GetCitiesInProvinceContext(ctx context.Context, provinceID string) (cities []City, err error) {
 	if provinceID == "" {
 		return nil, fmt.Errorf("provinceID must be specified")
 	}
+	ctx, span := r.startSpan(ctx, "GetCitiesInProvince")
+	defer func() { finishSpan(span, err) }()
+	span.SetAttribute("rajaongkir.province_id", provinceID)
 	re := &citiesResponse{}
 	endpoint := fmt.Sprintf("%s?province=%s", cityEndpoint, provinceID)
-	err := r.sendRequest(http.MethodGet, endpoint, "", re)
+	err = r.sendRequest(ctx, http.MethodGet, endpoint, "", re)
 	if err != nil {
 		return []City{}, err
 	}
-	cities := re.Rajaongkir.Results
+	err = checkStatus(&re.Rajaongkir.Status)
+	if err != nil {
+		return []City{}, err
+	}
+	cities = re.Rajaongkir.Results
 	return cities, nil
 }
 
 // GetCity fetches a specific city
 // matching a given provinceID and cityID
 func (r *RajaOngkir) GetCity(provinceID, cityID string) (City, error) {
+	return r.GetCityContext(context.Background(), provinceID, cityID)
+}
+
+// GetCityContext is like GetCity but carries ctx
+func (r *RajaOngkir) GetCityContext(ctx context.Context, provinceID, cityID string) (city City, err error) {
 	if provinceID == "" || cityID == "" {
 		return City{}, fmt.Errorf("provinceID/cityID must be specified")
 	}
+	ctx, span := r.startSpan(ctx, "GetCity")
+	defer func() { finishSpan(span, err) }()
+	span.SetAttribute("rajaongkir.province_id", provinceID)
+	span.SetAttribute("rajaongkir.city_id", cityID)
 	re := &cityResponse{}
 	endpoint := fmt.Sprintf("%s?province=%s&id=%s", cityEndpoint, provinceID, cityID)
-	err := r.sendRequest(http.MethodGet, endpoint, "", re)
+	err = r.sendRequest(ctx, http.MethodGet, endpoint, "", re)
 	if err != nil {
 		return City{}, err
 	}
-	city := re.Rajaongkir.Results
+	err = checkStatus(&re.Rajaongkir.Status)
+	if err != nil {
+		return City{}, err
+	}
+	city = re.Rajaongkir.Results
 	return city, nil
 }
 
 // GetCost fetches the shipping rate
-// given the origin, destination, weight, and courier service
+// given the origin, destination, weight in grams, and courier service.
+// The weight must be positive and, if the client was created WithTier, within the tier limit
 func (r *RajaOngkir) GetCost(origin, destination string, weight int, courier string) ([]Cost, error) {
-	queryString := fmt.Sprintf("origin=%s&destination=%s&weight=%d&courier=%s", origin, destination, weight, courier)
+	return r.GetCostContext(context.Background(), origin, destination, weight, courier)
+}
+
+// GetCostContext is like GetCost but carries ctx
+func (r *RajaOngkir) GetCostContext(ctx context.Context, origin, destination string, weight int, courier string) (costs []Cost, err error) {
+	ctx, span := r.startSpan(ctx, "GetCost")
+	defer func() { finishSpan(span, err) }()
+	span.SetAttribute("rajaongkir.origin", origin)
+	span.SetAttribute("rajaongkir.destination", destination)
+	span.SetAttribute("rajaongkir.weight", weight)
+	span.SetAttribute("rajaongkir.courier", courier)
+	err = Weight(weight).Validate(r.tier)
+	if err != nil {
+		return nil, err
+	}
+	req := CostRequest{Origin: origin, Destination: destination, Weight: weight, Courier: courier}
+	costs, err = r.requestCost(ctx, req)
+	if err != nil {
+		if estimated, ok := r.fallbackCosts(ctx, req, err); ok {
+			span.SetAttribute("rajaongkir.estimated", true)
+			return estimated, nil
+		}
+		return nil, err
+	}
+	r.recordCosts(req, costs)
+	return costs, nil
+}
+
+func (r *RajaOngkir) requestCost(ctx context.Context, req CostRequest) ([]Cost, error) {
+	queryString := fmt.Sprintf("origin=%s&destination=%s&weight=%d&courier=%s", req.Origin, req.Destination, req.Weight, req.Courier)
 	re := &costResponse{}
-	err := r.sendRequest(http.MethodPost, costEndpoint, queryString, re)
+	err := r.sendRequest(ctx, http.MethodPost, costEndpoint, queryString, re)
 	if err != nil {
 		return nil, err
 	}
@@ -209,6 +338,8 @@ func (r *RajaOngkir) GetCost(origin, destination string, weight int, courier str
 	if err != nil {
 		return nil, err
 	}
-	costs := re.Rajaongkir.Results[0].Costs
-	return costs, nil
+	if len(re.Rajaongkir.Results) == 0 {
+		return []Cost{}, nil
+	}
+	return re.Rajaongkir.Results[0].Costs, nil
 }
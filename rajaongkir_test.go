@@ -1,6 +1,7 @@
 package rajaongkir
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -230,7 +231,7 @@ const costRes string = `
    }
 }`
 
-func setupTest(jsonResponse string) (*httptest.Server, *RajaOngkir, *received) {
+func setupTest(jsonResponse string, opts ...Option) (*httptest.Server, *RajaOngkir, *received) {
 	rec := &received{}
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		rec.receivedMethod = r.Method
@@ -241,7 +242,7 @@ func setupTest(jsonResponse string) (*httptest.Server, *RajaOngkir, *received) {
 	ts := httptest.NewTLSServer(http.HandlerFunc(handler))
 	testClient := ts.Client()
 	hostname := strings.Replace(ts.URL, "https://", "", 1)
-	ro := New("APIKEY12345", hostname, testClient)
+	ro := New("APIKEY12345", hostname, testClient, opts...)
 	return ts, ro, rec
 }
 
@@ -382,3 +383,26 @@ func TestGetCost(t *testing.T) {
 		t.Errorf("Wrong method. Received %s, expected %s", rec.receivedEndpoint, expectedEndpoint)
 	}
 }
+
+func TestStatusError(t *testing.T) {
+	ts, ro, _ := setupTest(`{"rajaongkir":{"status":{"code":400,"description":"Invalid key."}}}`)
+	defer ts.Close()
+
+	calls := map[string]func() error{
+		"GetProvince":         func() error { _, err := ro.GetProvince("5"); return err },
+		"GetCities":           func() error { _, err := ro.GetCities(); return err },
+		"GetCitiesInProvince": func() error { _, err := ro.GetCitiesInProvince("5"); return err },
+		"GetCity":             func() error { _, err := ro.GetCity("5", "39"); return err },
+	}
+	for name, call := range calls {
+		err := call()
+		var statusErr *StatusError
+		if !errors.As(err, &statusErr) {
+			t.Errorf("Wrong error type from %s. Got %T, expected *StatusError", name, err)
+			continue
+		}
+		if statusErr.Code != 400 || err.Error() != "Invalid key." {
+			t.Errorf("Wrong status error from %s. Got %d %s, expected 400 Invalid key.", name, statusErr.Code, err)
+		}
+	}
+}
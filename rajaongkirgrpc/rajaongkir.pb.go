@@ -0,0 +1,803 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: rajaongkir.proto
+
+package rajaongkirgrpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Province stores the details of a province
+type Province struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProvinceId    string                 `protobuf:"bytes,1,opt,name=province_id,json=provinceId,proto3" json:"province_id,omitempty"`
+	Province      string                 `protobuf:"bytes,2,opt,name=province,proto3" json:"province,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Province) Reset() {
+	*x = Province{}
+	mi := &file_rajaongkir_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Province) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Province) ProtoMessage() {}
+
+func (x *Province) ProtoReflect() protoreflect.Message {
+	mi := &file_rajaongkir_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Province.ProtoReflect.Descriptor instead.
+func (*Province) Descriptor() ([]byte, []int) {
+	return file_rajaongkir_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Province) GetProvinceId() string {
+	if x != nil {
+		return x.ProvinceId
+	}
+	return ""
+}
+
+func (x *Province) GetProvince() string {
+	if x != nil {
+		return x.Province
+	}
+	return ""
+}
+
+// City stores the details of a city
+type City struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	CityId     string                 `protobuf:"bytes,1,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	ProvinceId string                 `protobuf:"bytes,2,opt,name=province_id,json=provinceId,proto3" json:"province_id,omitempty"`
+	Province   string                 `protobuf:"bytes,3,opt,name=province,proto3" json:"province,omitempty"`
+	// Kota or Kabupaten
+	Type          string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	CityName      string `protobuf:"bytes,5,opt,name=city_name,json=cityName,proto3" json:"city_name,omitempty"`
+	PostalCode    string `protobuf:"bytes,6,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *City) Reset() {
+	*x = City{}
+	mi := &file_rajaongkir_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *City) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*City) ProtoMessage() {}
+
+func (x *City) ProtoReflect() protoreflect.Message {
+	mi := &file_rajaongkir_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use City.ProtoReflect.Descriptor instead.
+func (*City) Descriptor() ([]byte, []int) {
+	return file_rajaongkir_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *City) GetCityId() string {
+	if x != nil {
+		return x.CityId
+	}
+	return ""
+}
+
+func (x *City) GetProvinceId() string {
+	if x != nil {
+		return x.ProvinceId
+	}
+	return ""
+}
+
+func (x *City) GetProvince() string {
+	if x != nil {
+		return x.Province
+	}
+	return ""
+}
+
+func (x *City) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *City) GetCityName() string {
+	if x != nil {
+		return x.CityName
+	}
+	return ""
+}
+
+func (x *City) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+// CostDetail is the price and estimated delivery time of a service
+type CostDetail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         int32                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Etd           string                 `protobuf:"bytes,2,opt,name=etd,proto3" json:"etd,omitempty"`
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CostDetail) Reset() {
+	*x = CostDetail{}
+	mi := &file_rajaongkir_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CostDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CostDetail) ProtoMessage() {}
+
+func (x *CostDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_rajaongkir_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CostDetail.ProtoReflect.Descriptor instead.
+func (*CostDetail) Descriptor() ([]byte, []int) {
+	return file_rajaongkir_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CostDetail) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *CostDetail) GetEtd() string {
+	if x != nil {
+		return x.Etd
+	}
+	return ""
+}
+
+func (x *CostDetail) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// Cost stores the details of the shipping cost
+type Cost struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Service     string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Cost        []*CostDetail          `protobuf:"bytes,3,rep,name=cost,proto3" json:"cost,omitempty"`
+	// estimated is set when the cost came from a fallback instead of RajaOngkir
+	Estimated     bool `protobuf:"varint,4,opt,name=estimated,proto3" json:"estimated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cost) Reset() {
+	*x = Cost{}
+	mi := &file_rajaongkir_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cost) ProtoMessage() {}
+
+func (x *Cost) ProtoReflect() protoreflect.Message {
+	mi := &file_rajaongkir_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cost.ProtoReflect.Descriptor instead.
+func (*Cost) Descriptor() ([]byte, []int) {
+	return file_rajaongkir_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Cost) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *Cost) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Cost) GetCost() []*CostDetail {
+	if x != nil {
+		return x.Cost
+	}
+	return nil
+}
+
+func (x *Cost) GetEstimated() bool {
+	if x != nil {
+		return x.Estimated
+	}
+	return false
+}
+
+type GetProvincesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProvincesRequest) Reset() {
+	*x = GetProvincesRequest{}
+	mi := &file_rajaongkir_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProvincesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProvincesRequest) ProtoMessage() {}
+
+func (x *GetProvincesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rajaongkir_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProvincesRequest.ProtoReflect.Descriptor instead.
+func (*GetProvincesRequest) Descriptor() ([]byte, []int) {
+	return file_rajaongkir_proto_rawDescGZIP(), []int{4}
+}
+
+type GetProvincesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provinces     []*Province            `protobuf:"bytes,1,rep,name=provinces,proto3" json:"provinces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProvincesResponse) Reset() {
+	*x = GetProvincesResponse{}
+	mi := &file_rajaongkir_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProvincesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProvincesResponse) ProtoMessage() {}
+
+func (x *GetProvincesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rajaongkir_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProvincesResponse.ProtoReflect.Descriptor instead.
+func (*GetProvincesResponse) Descriptor() ([]byte, []int) {
+	return file_rajaongkir_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetProvincesResponse) GetProvinces() []*Province {
+	if x != nil {
+		return x.Provinces
+	}
+	return nil
+}
+
+type GetProvinceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProvinceId    string                 `protobuf:"bytes,1,opt,name=province_id,json=provinceId,proto3" json:"province_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProvinceRequest) Reset() {
+	*x = GetProvinceRequest{}
+	mi := &file_rajaongkir_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProvinceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProvinceRequest) ProtoMessage() {}
+
+func (x *GetProvinceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rajaongkir_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProvinceRequest.ProtoReflect.Descriptor instead.
+func (*GetProvinceRequest) Descriptor() ([]byte, []int) {
+	return file_rajaongkir_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetProvinceRequest) GetProvinceId() string {
+	if x != nil {
+		return x.ProvinceId
+	}
+	return ""
+}
+
+type GetCitiesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only list cities in this province if set
+	ProvinceId    string `protobuf:"bytes,1,opt,name=province_id,json=provinceId,proto3" json:"province_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCitiesRequest) Reset() {
+	*x = GetCitiesRequest{}
+	mi := &file_rajaongkir_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCitiesRequest) ProtoMessage() {}
+
+func (x *GetCitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rajaongkir_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetCitiesRequest) Descriptor() ([]byte, []int) {
+	return file_rajaongkir_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetCitiesRequest) GetProvinceId() string {
+	if x != nil {
+		return x.ProvinceId
+	}
+	return ""
+}
+
+type GetCitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cities        []*City                `protobuf:"bytes,1,rep,name=cities,proto3" json:"cities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCitiesResponse) Reset() {
+	*x = GetCitiesResponse{}
+	mi := &file_rajaongkir_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCitiesResponse) ProtoMessage() {}
+
+func (x *GetCitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rajaongkir_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCitiesResponse.ProtoReflect.Descriptor instead.
+func (*GetCitiesResponse) Descriptor() ([]byte, []int) {
+	return file_rajaongkir_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetCitiesResponse) GetCities() []*City {
+	if x != nil {
+		return x.Cities
+	}
+	return nil
+}
+
+type GetCityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProvinceId    string                 `protobuf:"bytes,1,opt,name=province_id,json=provinceId,proto3" json:"province_id,omitempty"`
+	CityId        string                 `protobuf:"bytes,2,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCityRequest) Reset() {
+	*x = GetCityRequest{}
+	mi := &file_rajaongkir_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCityRequest) ProtoMessage() {}
+
+func (x *GetCityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rajaongkir_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCityRequest.ProtoReflect.Descriptor instead.
+func (*GetCityRequest) Descriptor() ([]byte, []int) {
+	return file_rajaongkir_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetCityRequest) GetProvinceId() string {
+	if x != nil {
+		return x.ProvinceId
+	}
+	return ""
+}
+
+func (x *GetCityRequest) GetCityId() string {
+	if x != nil {
+		return x.CityId
+	}
+	return ""
+}
+
+type GetCostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Origin city ID
+	Origin string `protobuf:"bytes,1,opt,name=origin,proto3" json:"origin,omitempty"`
+	// Destination city ID
+	Destination string `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	// Weight in grams
+	Weight int32 `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	// Courier code such as jne, pos or tiki
+	Courier       string `protobuf:"bytes,4,opt,name=courier,proto3" json:"courier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCostRequest) Reset() {
+	*x = GetCostRequest{}
+	mi := &file_rajaongkir_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCostRequest) ProtoMessage() {}
+
+func (x *GetCostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rajaongkir_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCostRequest.ProtoReflect.Descriptor instead.
+func (*GetCostRequest) Descriptor() ([]byte, []int) {
+	return file_rajaongkir_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetCostRequest) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *GetCostRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *GetCostRequest) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *GetCostRequest) GetCourier() string {
+	if x != nil {
+		return x.Courier
+	}
+	return ""
+}
+
+type GetCostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Costs         []*Cost                `protobuf:"bytes,1,rep,name=costs,proto3" json:"costs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCostResponse) Reset() {
+	*x = GetCostResponse{}
+	mi := &file_rajaongkir_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCostResponse) ProtoMessage() {}
+
+func (x *GetCostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rajaongkir_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCostResponse.ProtoReflect.Descriptor instead.
+func (*GetCostResponse) Descriptor() ([]byte, []int) {
+	return file_rajaongkir_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetCostResponse) GetCosts() []*Cost {
+	if x != nil {
+		return x.Costs
+	}
+	return nil
+}
+
+var File_rajaongkir_proto protoreflect.FileDescriptor
+
+const file_rajaongkir_proto_rawDesc = "" +
+	"\n" +
+	"\x10rajaongkir.proto\x12\rrajaongkir.v1\"G\n" +
+	"\bProvince\x12\x1f\n" +
+	"\vprovince_id\x18\x01 \x01(\tR\n" +
+	"provinceId\x12\x1a\n" +
+	"\bprovince\x18\x02 \x01(\tR\bprovince\"\xae\x01\n" +
+	"\x04City\x12\x17\n" +
+	"\acity_id\x18\x01 \x01(\tR\x06cityId\x12\x1f\n" +
+	"\vprovince_id\x18\x02 \x01(\tR\n" +
+	"provinceId\x12\x1a\n" +
+	"\bprovince\x18\x03 \x01(\tR\bprovince\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x1b\n" +
+	"\tcity_name\x18\x05 \x01(\tR\bcityName\x12\x1f\n" +
+	"\vpostal_code\x18\x06 \x01(\tR\n" +
+	"postalCode\"H\n" +
+	"\n" +
+	"CostDetail\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x05R\x05value\x12\x10\n" +
+	"\x03etd\x18\x02 \x01(\tR\x03etd\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\"\x8f\x01\n" +
+	"\x04Cost\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12-\n" +
+	"\x04cost\x18\x03 \x03(\v2\x19.rajaongkir.v1.CostDetailR\x04cost\x12\x1c\n" +
+	"\testimated\x18\x04 \x01(\bR\testimated\"\x15\n" +
+	"\x13GetProvincesRequest\"M\n" +
+	"\x14GetProvincesResponse\x125\n" +
+	"\tprovinces\x18\x01 \x03(\v2\x17.rajaongkir.v1.ProvinceR\tprovinces\"5\n" +
+	"\x12GetProvinceRequest\x12\x1f\n" +
+	"\vprovince_id\x18\x01 \x01(\tR\n" +
+	"provinceId\"3\n" +
+	"\x10GetCitiesRequest\x12\x1f\n" +
+	"\vprovince_id\x18\x01 \x01(\tR\n" +
+	"provinceId\"@\n" +
+	"\x11GetCitiesResponse\x12+\n" +
+	"\x06cities\x18\x01 \x03(\v2\x13.rajaongkir.v1.CityR\x06cities\"J\n" +
+	"\x0eGetCityRequest\x12\x1f\n" +
+	"\vprovince_id\x18\x01 \x01(\tR\n" +
+	"provinceId\x12\x17\n" +
+	"\acity_id\x18\x02 \x01(\tR\x06cityId\"|\n" +
+	"\x0eGetCostRequest\x12\x16\n" +
+	"\x06origin\x18\x01 \x01(\tR\x06origin\x12 \n" +
+	"\vdestination\x18\x02 \x01(\tR\vdestination\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x05R\x06weight\x12\x18\n" +
+	"\acourier\x18\x04 \x01(\tR\acourier\"<\n" +
+	"\x0fGetCostResponse\x12)\n" +
+	"\x05costs\x18\x01 \x03(\v2\x13.rajaongkir.v1.CostR\x05costs2\x87\x03\n" +
+	"\bShipping\x12W\n" +
+	"\fGetProvinces\x12\".rajaongkir.v1.GetProvincesRequest\x1a#.rajaongkir.v1.GetProvincesResponse\x12I\n" +
+	"\vGetProvince\x12!.rajaongkir.v1.GetProvinceRequest\x1a\x17.rajaongkir.v1.Province\x12N\n" +
+	"\tGetCities\x12\x1f.rajaongkir.v1.GetCitiesRequest\x1a .rajaongkir.v1.GetCitiesResponse\x12=\n" +
+	"\aGetCity\x12\x1d.rajaongkir.v1.GetCityRequest\x1a\x13.rajaongkir.v1.City\x12H\n" +
+	"\aGetCost\x12\x1d.rajaongkir.v1.GetCostRequest\x1a\x1e.rajaongkir.v1.GetCostResponseB5Z3github.com/GreenGeorge/go-rajaongkir/rajaongkirgrpcb\x06proto3"
+
+var (
+	file_rajaongkir_proto_rawDescOnce sync.Once
+	file_rajaongkir_proto_rawDescData []byte
+)
+
+func file_rajaongkir_proto_rawDescGZIP() []byte {
+	file_rajaongkir_proto_rawDescOnce.Do(func() {
+		file_rajaongkir_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rajaongkir_proto_rawDesc), len(file_rajaongkir_proto_rawDesc)))
+	})
+	return file_rajaongkir_proto_rawDescData
+}
+
+var file_rajaongkir_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_rajaongkir_proto_goTypes = []any{
+	(*Province)(nil),             // 0: rajaongkir.v1.Province
+	(*City)(nil),                 // 1: rajaongkir.v1.City
+	(*CostDetail)(nil),           // 2: rajaongkir.v1.CostDetail
+	(*Cost)(nil),                 // 3: rajaongkir.v1.Cost
+	(*GetProvincesRequest)(nil),  // 4: rajaongkir.v1.GetProvincesRequest
+	(*GetProvincesResponse)(nil), // 5: rajaongkir.v1.GetProvincesResponse
+	(*GetProvinceRequest)(nil),   // 6: rajaongkir.v1.GetProvinceRequest
+	(*GetCitiesRequest)(nil),     // 7: rajaongkir.v1.GetCitiesRequest
+	(*GetCitiesResponse)(nil),    // 8: rajaongkir.v1.GetCitiesResponse
+	(*GetCityRequest)(nil),       // 9: rajaongkir.v1.GetCityRequest
+	(*GetCostRequest)(nil),       // 10: rajaongkir.v1.GetCostRequest
+	(*GetCostResponse)(nil),      // 11: rajaongkir.v1.GetCostResponse
+}
+var file_rajaongkir_proto_depIdxs = []int32{
+	2,  // 0: rajaongkir.v1.Cost.cost:type_name -> rajaongkir.v1.CostDetail
+	0,  // 1: rajaongkir.v1.GetProvincesResponse.provinces:type_name -> rajaongkir.v1.Province
+	1,  // 2: rajaongkir.v1.GetCitiesResponse.cities:type_name -> rajaongkir.v1.City
+	3,  // 3: rajaongkir.v1.GetCostResponse.costs:type_name -> rajaongkir.v1.Cost
+	4,  // 4: rajaongkir.v1.Shipping.GetProvinces:input_type -> rajaongkir.v1.GetProvincesRequest
+	6,  // 5: rajaongkir.v1.Shipping.GetProvince:input_type -> rajaongkir.v1.GetProvinceRequest
+	7,  // 6: rajaongkir.v1.Shipping.GetCities:input_type -> rajaongkir.v1.GetCitiesRequest
+	9,  // 7: rajaongkir.v1.Shipping.GetCity:input_type -> rajaongkir.v1.GetCityRequest
+	10, // 8: rajaongkir.v1.Shipping.GetCost:input_type -> rajaongkir.v1.GetCostRequest
+	5,  // 9: rajaongkir.v1.Shipping.GetProvinces:output_type -> rajaongkir.v1.GetProvincesResponse
+	0,  // 10: rajaongkir.v1.Shipping.GetProvince:output_type -> rajaongkir.v1.Province
+	8,  // 11: rajaongkir.v1.Shipping.GetCities:output_type -> rajaongkir.v1.GetCitiesResponse
+	1,  // 12: rajaongkir.v1.Shipping.GetCity:output_type -> rajaongkir.v1.City
+	11, // 13: rajaongkir.v1.Shipping.GetCost:output_type -> rajaongkir.v1.GetCostResponse
+	9,  // [9:14] is the sub-list for method output_type
+	4,  // [4:9] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_rajaongkir_proto_init() }
+func file_rajaongkir_proto_init() {
+	if File_rajaongkir_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rajaongkir_proto_rawDesc), len(file_rajaongkir_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rajaongkir_proto_goTypes,
+		DependencyIndexes: file_rajaongkir_proto_depIdxs,
+		MessageInfos:      file_rajaongkir_proto_msgTypes,
+	}.Build()
+	File_rajaongkir_proto = out.File
+	file_rajaongkir_proto_goTypes = nil
+	file_rajaongkir_proto_depIdxs = nil
+}
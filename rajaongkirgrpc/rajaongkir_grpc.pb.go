@@ -0,0 +1,287 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: rajaongkir.proto
+
+package rajaongkirgrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Shipping_GetProvinces_FullMethodName = "/rajaongkir.v1.Shipping/GetProvinces"
+	Shipping_GetProvince_FullMethodName  = "/rajaongkir.v1.Shipping/GetProvince"
+	Shipping_GetCities_FullMethodName    = "/rajaongkir.v1.Shipping/GetCities"
+	Shipping_GetCity_FullMethodName      = "/rajaongkir.v1.Shipping/GetCity"
+	Shipping_GetCost_FullMethodName      = "/rajaongkir.v1.Shipping/GetCost"
+)
+
+// ShippingClient is the client API for Shipping service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Shipping serves RajaOngkir lookups and shipping quotes
+type ShippingClient interface {
+	// GetProvinces lists every province
+	GetProvinces(ctx context.Context, in *GetProvincesRequest, opts ...grpc.CallOption) (*GetProvincesResponse, error)
+	// GetProvince fetches a province by ID
+	GetProvince(ctx context.Context, in *GetProvinceRequest, opts ...grpc.CallOption) (*Province, error)
+	// GetCities lists cities, optionally only those in a province
+	GetCities(ctx context.Context, in *GetCitiesRequest, opts ...grpc.CallOption) (*GetCitiesResponse, error)
+	// GetCity fetches a city by province and city ID
+	GetCity(ctx context.Context, in *GetCityRequest, opts ...grpc.CallOption) (*City, error)
+	// GetCost quotes shipping from origin to destination
+	GetCost(ctx context.Context, in *GetCostRequest, opts ...grpc.CallOption) (*GetCostResponse, error)
+}
+
+type shippingClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewShippingClient(cc grpc.ClientConnInterface) ShippingClient {
+	return &shippingClient{cc}
+}
+
+func (c *shippingClient) GetProvinces(ctx context.Context, in *GetProvincesRequest, opts ...grpc.CallOption) (*GetProvincesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProvincesResponse)
+	err := c.cc.Invoke(ctx, Shipping_GetProvinces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shippingClient) GetProvince(ctx context.Context, in *GetProvinceRequest, opts ...grpc.CallOption) (*Province, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Province)
+	err := c.cc.Invoke(ctx, Shipping_GetProvince_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shippingClient) GetCities(ctx context.Context, in *GetCitiesRequest, opts ...grpc.CallOption) (*GetCitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCitiesResponse)
+	err := c.cc.Invoke(ctx, Shipping_GetCities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shippingClient) GetCity(ctx context.Context, in *GetCityRequest, opts ...grpc.CallOption) (*City, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(City)
+	err := c.cc.Invoke(ctx, Shipping_GetCity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shippingClient) GetCost(ctx context.Context, in *GetCostRequest, opts ...grpc.CallOption) (*GetCostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCostResponse)
+	err := c.cc.Invoke(ctx, Shipping_GetCost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ShippingServer is the server API for Shipping service.
+// All implementations must embed UnimplementedShippingServer
+// for forward compatibility.
+//
+// Shipping serves RajaOngkir lookups and shipping quotes
+type ShippingServer interface {
+	// GetProvinces lists every province
+	GetProvinces(context.Context, *GetProvincesRequest) (*GetProvincesResponse, error)
+	// GetProvince fetches a province by ID
+	GetProvince(context.Context, *GetProvinceRequest) (*Province, error)
+	// GetCities lists cities, optionally only those in a province
+	GetCities(context.Context, *GetCitiesRequest) (*GetCitiesResponse, error)
+	// GetCity fetches a city by province and city ID
+	GetCity(context.Context, *GetCityRequest) (*City, error)
+	// GetCost quotes shipping from origin to destination
+	GetCost(context.Context, *GetCostRequest) (*GetCostResponse, error)
+	mustEmbedUnimplementedShippingServer()
+}
+
+// UnimplementedShippingServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedShippingServer struct{}
+
+func (UnimplementedShippingServer) GetProvinces(context.Context, *GetProvincesRequest) (*GetProvincesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProvinces not implemented")
+}
+func (UnimplementedShippingServer) GetProvince(context.Context, *GetProvinceRequest) (*Province, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProvince not implemented")
+}
+func (UnimplementedShippingServer) GetCities(context.Context, *GetCitiesRequest) (*GetCitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCities not implemented")
+}
+func (UnimplementedShippingServer) GetCity(context.Context, *GetCityRequest) (*City, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCity not implemented")
+}
+func (UnimplementedShippingServer) GetCost(context.Context, *GetCostRequest) (*GetCostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCost not implemented")
+}
+func (UnimplementedShippingServer) mustEmbedUnimplementedShippingServer() {}
+func (UnimplementedShippingServer) testEmbeddedByValue()                  {}
+
+// UnsafeShippingServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ShippingServer will
+// result in compilation errors.
+type UnsafeShippingServer interface {
+	mustEmbedUnimplementedShippingServer()
+}
+
+func RegisterShippingServer(s grpc.ServiceRegistrar, srv ShippingServer) {
+	// If the following call pancis, it indicates UnimplementedShippingServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Shipping_ServiceDesc, srv)
+}
+
+func _Shipping_GetProvinces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProvincesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShippingServer).GetProvinces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Shipping_GetProvinces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShippingServer).GetProvinces(ctx, req.(*GetProvincesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shipping_GetProvince_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProvinceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShippingServer).GetProvince(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Shipping_GetProvince_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShippingServer).GetProvince(ctx, req.(*GetProvinceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shipping_GetCities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShippingServer).GetCities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Shipping_GetCities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShippingServer).GetCities(ctx, req.(*GetCitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shipping_GetCity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShippingServer).GetCity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Shipping_GetCity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShippingServer).GetCity(ctx, req.(*GetCityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shipping_GetCost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShippingServer).GetCost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Shipping_GetCost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShippingServer).GetCost(ctx, req.(*GetCostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Shipping_ServiceDesc is the grpc.ServiceDesc for Shipping service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Shipping_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rajaongkir.v1.Shipping",
+	HandlerType: (*ShippingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetProvinces",
+			Handler:    _Shipping_GetProvinces_Handler,
+		},
+		{
+			MethodName: "GetProvince",
+			Handler:    _Shipping_GetProvince_Handler,
+		},
+		{
+			MethodName: "GetCities",
+			Handler:    _Shipping_GetCities_Handler,
+		},
+		{
+			MethodName: "GetCity",
+			Handler:    _Shipping_GetCity_Handler,
+		},
+		{
+			MethodName: "GetCost",
+			Handler:    _Shipping_GetCost_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rajaongkir.proto",
+}
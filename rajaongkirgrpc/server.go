@@ -0,0 +1,270 @@
+// Package rajaongkirgrpc serves RajaOngkir lookups and quotes over gRPC.
+//
+// The Shipping service is defined in rajaongkir.proto so services in any language
+// can generate a client for it; Go services can use NewShippingClient directly.
+// Server implements it with a rajaongkir.Client, caches successful responses
+// and maps errors to gRPC status codes.
+//
+//	s := grpc.NewServer()
+//	rajaongkirgrpc.RegisterShippingServer(s, rajaongkirgrpc.NewServer(rajaongkir.New(apiKey, baseURL, nil)))
+//	s.Serve(lis)
+package rajaongkirgrpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative rajaongkir.proto
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+	"github.com/GreenGeorge/go-rajaongkir/internal/ttlcache"
+)
+
+// Server implements ShippingServer with a rajaongkir.Client
+type Server struct {
+	UnimplementedShippingServer
+
+	client rajaongkir.Client
+	cache  *ttlcache.Cache[proto.Message]
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithCacheTTL sets how long successful responses are cached. Defaults to 10 minutes,
+// 0 disables caching
+func WithCacheTTL(d time.Duration) Option {
+	return func(s *Server) {
+		s.cache.TTL = d
+	}
+}
+
+// WithMetrics tells m whether each lookup was served from the cache
+func WithMetrics(m rajaongkir.CacheMetrics) Option {
+	return func(s *Server) {
+		s.cache.Metrics = m
+	}
+}
+
+// WithTracer traces each lookup with t, in a span telling whether it was served from the cache.
+// Give the client the same tracer to see its calls as children of that span
+func WithTracer(t rajaongkir.Tracer) Option {
+	return func(s *Server) {
+		s.cache.Tracer = t
+	}
+}
+
+// NewServer returns a Server serving lookups with c
+func NewServer(c rajaongkir.Client, opts ...Option) *Server {
+	s := &Server{
+		client: c,
+		cache:  ttlcache.New[proto.Message](time.Minute * 10),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// cached returns a copy of the response cached for method and req,
+// or calls f and caches its result
+func cached[M proto.Message](ctx context.Context, s *Server, method string, req proto.Message, f func(ctx context.Context) (M, error)) (M, error) {
+	var zero M
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return zero, status.Error(codes.Internal, err.Error())
+	}
+	res, err := s.cache.Load(ctx, method, method+"/"+string(b), func(ctx context.Context) (proto.Message, error) {
+		return f(ctx)
+	})
+	if err != nil {
+		return zero, toStatus(err)
+	}
+	return proto.Clone(res).(M), nil
+}
+
+// toStatus maps an error from the client to a gRPC status
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var statusErr *rajaongkir.StatusError
+	var responseErr *rajaongkir.ResponseError
+	var urlErr *url.Error
+	code := codes.Unknown
+	switch {
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, rajaongkir.ErrCircuitOpen):
+		code = codes.Unavailable
+	case errors.Is(err, rajaongkir.ErrInvalidWeight), errors.Is(err, rajaongkir.ErrWeightLimit), errors.Is(err, rajaongkir.ErrItemTooHeavy):
+		code = codes.InvalidArgument
+	case errors.As(err, &statusErr):
+		switch {
+		case statusErr.Code == 400:
+			code = codes.InvalidArgument
+		case statusErr.Code == 401:
+			code = codes.Unauthenticated
+		case statusErr.Code == 403:
+			code = codes.PermissionDenied
+		case statusErr.Code == 404:
+			code = codes.NotFound
+		case statusErr.Code == 429:
+			code = codes.ResourceExhausted
+		case statusErr.Code >= 500:
+			code = codes.Unavailable
+		}
+	case errors.As(err, &responseErr):
+		code = codes.Internal
+		if responseErr.StatusCode >= 500 {
+			code = codes.Unavailable
+		}
+	case errors.As(err, &urlErr):
+		code = codes.Unavailable
+	}
+	return status.Error(code, err.Error())
+}
+
+func invalid(field string, err error) error {
+	return status.Errorf(codes.InvalidArgument, "%s: %s", field, err)
+}
+
+// GetProvinces lists every province
+func (s *Server) GetProvinces(ctx context.Context, req *GetProvincesRequest) (*GetProvincesResponse, error) {
+	return cached(ctx, s, "GetProvinces", req, func(ctx context.Context) (*GetProvincesResponse, error) {
+		provinces, err := s.client.GetProvincesContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res := &GetProvincesResponse{}
+		for _, p := range provinces {
+			res.Provinces = append(res.Provinces, toProvince(p))
+		}
+		return res, nil
+	})
+}
+
+// GetProvince fetches a province by ID
+func (s *Server) GetProvince(ctx context.Context, req *GetProvinceRequest) (*Province, error) {
+	if _, err := rajaongkir.ParseProvinceID(req.GetProvinceId()); err != nil {
+		return nil, invalid("province_id", err)
+	}
+	return cached(ctx, s, "GetProvince", req, func(ctx context.Context) (*Province, error) {
+		p, err := s.client.GetProvinceContext(ctx, req.GetProvinceId())
+		if err != nil {
+			return nil, err
+		}
+		return toProvince(p), nil
+	})
+}
+
+// GetCities lists cities, only those in the province if one is set
+func (s *Server) GetCities(ctx context.Context, req *GetCitiesRequest) (*GetCitiesResponse, error) {
+	if req.GetProvinceId() != "" {
+		if _, err := rajaongkir.ParseProvinceID(req.GetProvinceId()); err != nil {
+			return nil, invalid("province_id", err)
+		}
+	}
+	return cached(ctx, s, "GetCities", req, func(ctx context.Context) (*GetCitiesResponse, error) {
+		var cities []rajaongkir.City
+		var err error
+		if req.GetProvinceId() == "" {
+			cities, err = s.client.GetCitiesContext(ctx)
+		} else {
+			cities, err = s.client.GetCitiesInProvinceContext(ctx, req.GetProvinceId())
+		}
+		if err != nil {
+			return nil, err
+		}
+		res := &GetCitiesResponse{}
+		for _, c := range cities {
+			res.Cities = append(res.Cities, toCity(c))
+		}
+		return res, nil
+	})
+}
+
+// GetCity fetches a city by province and city ID
+func (s *Server) GetCity(ctx context.Context, req *GetCityRequest) (*City, error) {
+	if _, err := rajaongkir.ParseProvinceID(req.GetProvinceId()); err != nil {
+		return nil, invalid("province_id", err)
+	}
+	if _, err := rajaongkir.ParseCityID(req.GetCityId()); err != nil {
+		return nil, invalid("city_id", err)
+	}
+	return cached(ctx, s, "GetCity", req, func(ctx context.Context) (*City, error) {
+		c, err := s.client.GetCityContext(ctx, req.GetProvinceId(), req.GetCityId())
+		if err != nil {
+			return nil, err
+		}
+		return toCity(c), nil
+	})
+}
+
+// GetCost quotes shipping from origin to destination
+func (s *Server) GetCost(ctx context.Context, req *GetCostRequest) (*GetCostResponse, error) {
+	if _, err := rajaongkir.ParseCityID(req.GetOrigin()); err != nil {
+		return nil, invalid("origin", err)
+	}
+	if _, err := rajaongkir.ParseCityID(req.GetDestination()); err != nil {
+		return nil, invalid("destination", err)
+	}
+	if req.GetWeight() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "weight: must be a positive number of grams")
+	}
+	if req.GetCourier() == "" {
+		return nil, status.Error(codes.InvalidArgument, "courier: is required")
+	}
+	return cached(ctx, s, "GetCost", req, func(ctx context.Context) (*GetCostResponse, error) {
+		costs, err := s.client.GetCostContext(ctx, req.GetOrigin(), req.GetDestination(), int(req.GetWeight()), req.GetCourier())
+		if err != nil {
+			return nil, err
+		}
+		res := &GetCostResponse{}
+		for _, c := range costs {
+			res.Costs = append(res.Costs, toCost(c))
+		}
+		return res, nil
+	})
+}
+
+func toProvince(p rajaongkir.Province) *Province {
+	return &Province{ProvinceId: string(p.ProvinceID), Province: p.Province}
+}
+
+func toCity(c rajaongkir.City) *City {
+	return &City{
+		CityId:     string(c.CityID),
+		ProvinceId: string(c.ProvinceID),
+		Province:   c.Province,
+		Type:       c.Type.String(),
+		CityName:   c.CityName,
+		PostalCode: c.PostalCode,
+	}
+}
+
+// Estimated reports whether any cost in r came from a rajaongkir.Fallback
+func (r *GetCostResponse) Estimated() bool {
+	for _, c := range r.GetCosts() {
+		if c.GetEstimated() {
+			return true
+		}
+	}
+	return false
+}
+
+func toCost(c rajaongkir.Cost) *Cost {
+	cost := &Cost{Service: c.Service, Description: c.Description, Estimated: c.Estimated}
+	for _, d := range c.Cost {
+		cost.Cost = append(cost.Cost, &CostDetail{Value: int32(d.Value), Etd: d.ETD, Note: d.Note})
+	}
+	return cost
+}
@@ -0,0 +1,191 @@
+package rajaongkirgrpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+	"github.com/GreenGeorge/go-rajaongkir/rajaongkirtest"
+)
+
+func setupServer(t *testing.T, opts ...Option) (ShippingClient, *rajaongkirtest.Server) {
+	return setupServerWithClient(t, nil, opts...)
+}
+
+// setupServerWithClient is like setupServer but configures the rajaongkir client with clientOpts
+func setupServerWithClient(t *testing.T, clientOpts []rajaongkir.Option, opts ...Option) (ShippingClient, *rajaongkirtest.Server) {
+	srv := rajaongkirtest.NewServer()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	RegisterShippingServer(s, NewServer(srv.NewClient(clientOpts...), opts...))
+	go s.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+		srv.Close()
+	})
+	return NewShippingClient(conn), srv
+}
+
+func TestServerLookups(t *testing.T) {
+	c, _ := setupServer(t)
+	ctx := context.Background()
+
+	provinces, err := c.GetProvinces(ctx, &GetProvincesRequest{})
+	if err != nil || len(provinces.GetProvinces()) != 34 {
+		t.Errorf("Wrong number of provinces. Got %d (%v), expected 34", len(provinces.GetProvinces()), err)
+	}
+
+	province, err := c.GetProvince(ctx, &GetProvinceRequest{ProvinceId: "5"})
+	if err != nil || province.GetProvince() != "DI Yogyakarta" {
+		t.Errorf("Wrong province. Got %v (%v), expected DI Yogyakarta", province, err)
+	}
+
+	cities, err := c.GetCities(ctx, &GetCitiesRequest{ProvinceId: "5"})
+	if err != nil || len(cities.GetCities()) != 5 {
+		t.Errorf("Wrong number of cities. Got %d (%v), expected 5", len(cities.GetCities()), err)
+	}
+
+	city, err := c.GetCity(ctx, &GetCityRequest{ProvinceId: "5", CityId: "39"})
+	if err != nil || city.GetCityName() != "Bantul" || city.GetType() != "Kabupaten" {
+		t.Errorf("Wrong city. Got %v (%v), expected Kabupaten Bantul", city, err)
+	}
+
+	costs, err := c.GetCost(ctx, &GetCostRequest{Origin: "501", Destination: "114", Weight: 1700, Courier: "jne"})
+	if err != nil || len(costs.GetCosts()) != 3 {
+		t.Fatalf("Wrong number of costs. Got %d (%v), expected 3", len(costs.GetCosts()), err)
+	}
+	if cost := costs.GetCosts()[0]; cost.GetService() != "OKE" || cost.GetCost()[0].GetValue() != 38000 {
+		t.Errorf("Wrong cost. Got %v, expected OKE at 38000", cost)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	c, srv := setupServer(t)
+	ctx := context.Background()
+
+	tables := []struct {
+		req      *GetCostRequest
+		fault    *rajaongkirtest.Fault
+		expected codes.Code
+	}{
+		{&GetCostRequest{Origin: "abc", Destination: "114", Weight: 1700, Courier: "jne"}, nil, codes.InvalidArgument},
+		{&GetCostRequest{Origin: "501", Destination: "114", Weight: 0, Courier: "jne"}, nil, codes.InvalidArgument},
+		{&GetCostRequest{Origin: "501", Destination: "114", Weight: 1700}, nil, codes.InvalidArgument},
+		{&GetCostRequest{Origin: "501", Destination: "114", Weight: 1700, Courier: "jne"}, &rajaongkirtest.Fault{Code: 400, Description: "Invalid key."}, codes.InvalidArgument},
+		{&GetCostRequest{Origin: "501", Destination: "114", Weight: 1700, Courier: "jne"}, &rajaongkirtest.Fault{HTTPStatus: 502, Body: "<html>502 Bad Gateway</html>"}, codes.Unavailable},
+	}
+
+	for _, table := range tables {
+		if table.fault != nil {
+			srv.FailNext("/cost", *table.fault)
+		}
+		_, err := c.GetCost(ctx, table.req)
+		if status.Code(err) != table.expected {
+			t.Errorf("Wrong code for %v. Got %s (%v), expected %s", table.req, status.Code(err), err, table.expected)
+		}
+	}
+}
+
+func TestServerWeightErrors(t *testing.T) {
+	c, _ := setupServerWithClient(t, []rajaongkir.Option{rajaongkir.WithTier(rajaongkir.TierStarter)})
+	_, err := c.GetCost(context.Background(), &GetCostRequest{Origin: "501", Destination: "114", Weight: 40000, Courier: "jne"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Wrong code for a weight over the tier limit. Got %s (%v), expected InvalidArgument", status.Code(err), err)
+	}
+
+	for _, err := range []error{rajaongkir.ErrInvalidWeight, rajaongkir.ErrWeightLimit, fmt.Errorf("item 0: %w", rajaongkir.ErrItemTooHeavy)} {
+		if code := status.Code(toStatus(err)); code != codes.InvalidArgument {
+			t.Errorf("Wrong code for %v. Got %s, expected InvalidArgument", err, code)
+		}
+	}
+}
+
+func TestServerCache(t *testing.T) {
+	c, srv := setupServer(t)
+	ctx := context.Background()
+	req := &GetCostRequest{Origin: "501", Destination: "114", Weight: 1700, Courier: "jne"}
+
+	srv.FailNext("/cost", rajaongkirtest.Fault{Code: 400, Description: "Daily limit exceeded"})
+	c.GetCost(ctx, req)
+	first, _ := c.GetCost(ctx, req)
+	second, _ := c.GetCost(ctx, req)
+	if n := len(srv.Requests()); n != 2 {
+		t.Errorf("Wrong number of upstream requests. Got %d, expected 2", n)
+	}
+	if len(first.GetCosts()) != 3 || len(second.GetCosts()) != 3 {
+		t.Errorf("Wrong cached costs. Got %d and %d, expected 3", len(first.GetCosts()), len(second.GetCosts()))
+	}
+
+	c, srv = setupServer(t, WithCacheTTL(0))
+	c.GetCost(ctx, req)
+	c.GetCost(ctx, req)
+	if n := len(srv.Requests()); n != 2 {
+		t.Errorf("Expected caching to be disabled. Got %d upstream requests, expected 2", n)
+	}
+}
+
+func TestServerEstimates(t *testing.T) {
+	store, _ := rajaongkir.NewQuoteStore("")
+	c, srv := setupServerWithClient(t, []rajaongkir.Option{rajaongkir.WithFallback(store)})
+	ctx := context.Background()
+	req := &GetCostRequest{Origin: "501", Destination: "114", Weight: 1700, Courier: "jne"}
+
+	store.Record(rajaongkir.CostRequest{Origin: "501", Destination: "114", Weight: 1700, Courier: "jne"}, []rajaongkir.Cost{{Service: "REG"}})
+	srv.FailNext("/cost", rajaongkirtest.Fault{HTTPStatus: 502, Body: "<html>502 Bad Gateway</html>"})
+	res, err := c.GetCost(ctx, req)
+	if err != nil || len(res.GetCosts()) != 1 || !res.GetCosts()[0].GetEstimated() {
+		t.Fatalf("Expected an estimated cost. Got %v, %v", res, err)
+	}
+
+	res, err = c.GetCost(ctx, req)
+	if err != nil || len(res.GetCosts()) != 3 || res.GetCosts()[0].GetEstimated() {
+		t.Errorf("Expected real costs once the API recovered. Got %v, %v", res, err)
+	}
+	if n := len(srv.Requests()); n != 2 {
+		t.Errorf("Expected estimates not to be cached. Got %d upstream requests, expected 2", n)
+	}
+}
+
+type fakeCacheMetrics struct {
+	mu      sync.Mutex
+	lookups []string
+}
+
+func (m *fakeCacheMetrics) ObserveCache(endpoint string, hit bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lookups = append(m.lookups, fmt.Sprintf("%s %t", endpoint, hit))
+}
+
+func TestServerCacheMetrics(t *testing.T) {
+	m := &fakeCacheMetrics{}
+	c, _ := setupServer(t, WithMetrics(m))
+	ctx := context.Background()
+
+	c.GetProvince(ctx, &GetProvinceRequest{ProvinceId: "5"})
+	c.GetProvince(ctx, &GetProvinceRequest{ProvinceId: "5"})
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if got := strings.Join(m.lookups, ", "); got != "GetProvince false, GetProvince true" {
+		t.Errorf("Wrong cache lookups. Got %s, expected a miss then a hit", got)
+	}
+}
@@ -0,0 +1,228 @@
+// Package rajaongkirmock provides a stub rajaongkir.Client.
+//
+//	m := &rajaongkirmock.Client{
+//		GetCostFunc: func(ctx context.Context, origin, destination string, weight int, courier string) ([]rajaongkir.Cost, error) {
+//			return []rajaongkir.Cost{{Service: "REG"}}, nil
+//		},
+//	}
+//	checkout := NewCheckout(m)
+package rajaongkirmock
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+)
+
+// ErrNotStubbed is returned by methods whose Func field is nil
+var ErrNotStubbed = errors.New("rajaongkirmock: method not stubbed")
+
+// Call is a recorded method call
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Client implements rajaongkir.Client by calling its Func fields.
+// A method and its Context variant share the same Func,
+// which receives context.Background() for the former.
+// GetCostsBatch falls back to GetCostFunc for every request
+// if GetCostsBatchFunc is nil
+type Client struct {
+	GetProvincesFunc        func(ctx context.Context) ([]rajaongkir.Province, error)
+	GetProvinceFunc         func(ctx context.Context, id string) (rajaongkir.Province, error)
+	GetCitiesFunc           func(ctx context.Context) ([]rajaongkir.City, error)
+	GetCitiesInProvinceFunc func(ctx context.Context, provinceID string) ([]rajaongkir.City, error)
+	GetCityFunc             func(ctx context.Context, provinceID, cityID string) (rajaongkir.City, error)
+	GetSubdistrictsFunc     func(ctx context.Context, cityID string) ([]rajaongkir.Subdistrict, error)
+	GetCostFunc             func(ctx context.Context, origin, destination string, weight int, courier string) ([]rajaongkir.Cost, error)
+	GetCostsBatchFunc       func(ctx context.Context, reqs []rajaongkir.CostRequest) []rajaongkir.CostResult
+	GetWaybillFunc          func(ctx context.Context, waybill, courier string) (rajaongkir.Waybill, error)
+	QuoteShipmentFunc       func(ctx context.Context, s rajaongkir.Shipment) (rajaongkir.ShipmentQuote, error)
+	QuoteItemsFunc          func(ctx context.Context, origin, destination, courier string, items []rajaongkir.Item, maxWeight int) (rajaongkir.ShipmentQuote, error)
+	CompareOriginsFunc      func(ctx context.Context, origins []string, destination string, p rajaongkir.Parcel, couriers []string, by rajaongkir.Ranking) (rajaongkir.OriginComparison, error)
+	VerifyFunc              func(ctx context.Context) ([]rajaongkir.KeyStatus, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ rajaongkir.Client = (*Client)(nil)
+
+// Calls returns the calls made so far
+func (c *Client) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Call(nil), c.calls...)
+}
+
+func (c *Client) record(method string, args ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, Call{Method: method, Args: args})
+}
+
+// GetProvinces calls GetProvincesFunc
+func (c *Client) GetProvinces() ([]rajaongkir.Province, error) {
+	return c.GetProvincesContext(context.Background())
+}
+
+// GetProvincesContext calls GetProvincesFunc
+func (c *Client) GetProvincesContext(ctx context.Context) ([]rajaongkir.Province, error) {
+	c.record("GetProvinces")
+	if c.GetProvincesFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return c.GetProvincesFunc(ctx)
+}
+
+// GetProvince calls GetProvinceFunc
+func (c *Client) GetProvince(id string) (rajaongkir.Province, error) {
+	return c.GetProvinceContext(context.Background(), id)
+}
+
+// GetProvinceContext calls GetProvinceFunc
+func (c *Client) GetProvinceContext(ctx context.Context, id string) (rajaongkir.Province, error) {
+	c.record("GetProvince", id)
+	if c.GetProvinceFunc == nil {
+		return rajaongkir.Province{}, ErrNotStubbed
+	}
+	return c.GetProvinceFunc(ctx, id)
+}
+
+// GetCities calls GetCitiesFunc
+func (c *Client) GetCities() ([]rajaongkir.City, error) {
+	return c.GetCitiesContext(context.Background())
+}
+
+// GetCitiesContext calls GetCitiesFunc
+func (c *Client) GetCitiesContext(ctx context.Context) ([]rajaongkir.City, error) {
+	c.record("GetCities")
+	if c.GetCitiesFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return c.GetCitiesFunc(ctx)
+}
+
+// GetCitiesInProvince calls GetCitiesInProvinceFunc
+func (c *Client) GetCitiesInProvince(provinceID string) ([]rajaongkir.City, error) {
+	return c.GetCitiesInProvinceContext(context.Background(), provinceID)
+}
+
+// GetCitiesInProvinceContext calls GetCitiesInProvinceFunc
+func (c *Client) GetCitiesInProvinceContext(ctx context.Context, provinceID string) ([]rajaongkir.City, error) {
+	c.record("GetCitiesInProvince", provinceID)
+	if c.GetCitiesInProvinceFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return c.GetCitiesInProvinceFunc(ctx, provinceID)
+}
+
+// GetCity calls GetCityFunc
+func (c *Client) GetCity(provinceID, cityID string) (rajaongkir.City, error) {
+	return c.GetCityContext(context.Background(), provinceID, cityID)
+}
+
+// GetCityContext calls GetCityFunc
+func (c *Client) GetCityContext(ctx context.Context, provinceID, cityID string) (rajaongkir.City, error) {
+	c.record("GetCity", provinceID, cityID)
+	if c.GetCityFunc == nil {
+		return rajaongkir.City{}, ErrNotStubbed
+	}
+	return c.GetCityFunc(ctx, provinceID, cityID)
+}
+
+// GetSubdistricts calls GetSubdistrictsFunc
+func (c *Client) GetSubdistricts(cityID string) ([]rajaongkir.Subdistrict, error) {
+	return c.GetSubdistrictsContext(context.Background(), cityID)
+}
+
+// GetSubdistrictsContext calls GetSubdistrictsFunc
+func (c *Client) GetSubdistrictsContext(ctx context.Context, cityID string) ([]rajaongkir.Subdistrict, error) {
+	c.record("GetSubdistricts", cityID)
+	if c.GetSubdistrictsFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return c.GetSubdistrictsFunc(ctx, cityID)
+}
+
+// GetCost calls GetCostFunc
+func (c *Client) GetCost(origin, destination string, weight int, courier string) ([]rajaongkir.Cost, error) {
+	return c.GetCostContext(context.Background(), origin, destination, weight, courier)
+}
+
+// GetCostContext calls GetCostFunc
+func (c *Client) GetCostContext(ctx context.Context, origin, destination string, weight int, courier string) ([]rajaongkir.Cost, error) {
+	c.record("GetCost", origin, destination, weight, courier)
+	if c.GetCostFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return c.GetCostFunc(ctx, origin, destination, weight, courier)
+}
+
+// GetCostsBatch calls GetCostsBatchFunc,
+// or GetCostContext for every request if it is nil
+func (c *Client) GetCostsBatch(ctx context.Context, reqs []rajaongkir.CostRequest) []rajaongkir.CostResult {
+	if c.GetCostsBatchFunc != nil {
+		c.record("GetCostsBatch", reqs)
+		return c.GetCostsBatchFunc(ctx, reqs)
+	}
+	results := make([]rajaongkir.CostResult, len(reqs))
+	for i, req := range reqs {
+		costs, err := c.GetCostContext(ctx, req.Origin, req.Destination, req.Weight, req.Courier)
+		results[i] = rajaongkir.CostResult{Request: req, Costs: costs, Err: err}
+	}
+	return results
+}
+
+// QuoteShipment calls QuoteShipmentFunc
+func (c *Client) QuoteShipment(ctx context.Context, s rajaongkir.Shipment) (rajaongkir.ShipmentQuote, error) {
+	c.record("QuoteShipment", s)
+	if c.QuoteShipmentFunc == nil {
+		return rajaongkir.ShipmentQuote{}, ErrNotStubbed
+	}
+	return c.QuoteShipmentFunc(ctx, s)
+}
+
+// QuoteItems calls QuoteItemsFunc
+func (c *Client) QuoteItems(ctx context.Context, origin, destination, courier string, items []rajaongkir.Item, maxWeight int) (rajaongkir.ShipmentQuote, error) {
+	c.record("QuoteItems", origin, destination, courier, items, maxWeight)
+	if c.QuoteItemsFunc == nil {
+		return rajaongkir.ShipmentQuote{}, ErrNotStubbed
+	}
+	return c.QuoteItemsFunc(ctx, origin, destination, courier, items, maxWeight)
+}
+
+// CompareOrigins calls CompareOriginsFunc
+func (c *Client) CompareOrigins(ctx context.Context, origins []string, destination string, p rajaongkir.Parcel, couriers []string, by rajaongkir.Ranking) (rajaongkir.OriginComparison, error) {
+	c.record("CompareOrigins", origins, destination, p, couriers, by)
+	if c.CompareOriginsFunc == nil {
+		return rajaongkir.OriginComparison{}, ErrNotStubbed
+	}
+	return c.CompareOriginsFunc(ctx, origins, destination, p, couriers, by)
+}
+
+// GetWaybill calls GetWaybillFunc
+func (c *Client) GetWaybill(waybill, courier string) (rajaongkir.Waybill, error) {
+	return c.GetWaybillContext(context.Background(), waybill, courier)
+}
+
+// GetWaybillContext calls GetWaybillFunc
+func (c *Client) GetWaybillContext(ctx context.Context, waybill, courier string) (rajaongkir.Waybill, error) {
+	c.record("GetWaybill", waybill, courier)
+	if c.GetWaybillFunc == nil {
+		return rajaongkir.Waybill{}, ErrNotStubbed
+	}
+	return c.GetWaybillFunc(ctx, waybill, courier)
+}
+
+// Verify calls VerifyFunc
+func (c *Client) Verify(ctx context.Context) ([]rajaongkir.KeyStatus, error) {
+	c.record("Verify")
+	if c.VerifyFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return c.VerifyFunc(ctx)
+}
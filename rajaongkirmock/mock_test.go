@@ -0,0 +1,74 @@
+package rajaongkirmock
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+)
+
+// quote stands in for a consumer that depends on rajaongkir.Client
+func quote(c rajaongkir.Client, courier string) (int, error) {
+	costs, err := c.GetCost("501", "114", 1700, courier)
+	if err != nil {
+		return 0, err
+	}
+	return costs[0].Cost[0].Value, nil
+}
+
+func TestClient(t *testing.T) {
+	m := &Client{
+		GetCostFunc: func(ctx context.Context, origin, destination string, weight int, courier string) ([]rajaongkir.Cost, error) {
+			if courier != "jne" {
+				return nil, errors.New("Courier tidak valid")
+			}
+			cost := rajaongkir.Cost{Service: "REG"}
+			cost.Cost = append(cost.Cost, struct {
+				Value int    `json:"value"`
+				ETD   string `json:"etd"`
+				Note  string `json:"note"`
+			}{Value: 44000, ETD: "2-3"})
+			return []rajaongkir.Cost{cost}, nil
+		},
+	}
+
+	value, err := quote(m, "jne")
+	if err != nil || value != 44000 {
+		t.Errorf("Wrong quote. Got %d (%v), expected 44000", value, err)
+	}
+	if _, err := quote(m, "xxx"); err == nil {
+		t.Errorf("Expected the stubbed error")
+	}
+
+	results := m.GetCostsBatch(context.Background(), []rajaongkir.CostRequest{{Origin: "501", Destination: "114", Weight: 1700, Courier: "jne"}})
+	if len(results) != 1 || results[0].Err != nil || len(results[0].Costs) != 1 {
+		t.Errorf("Wrong batch results. Got %+v", results)
+	}
+
+	expected := []Call{
+		{"GetCost", []interface{}{"501", "114", 1700, "jne"}},
+		{"GetCost", []interface{}{"501", "114", 1700, "xxx"}},
+		{"GetCost", []interface{}{"501", "114", 1700, "jne"}},
+	}
+	if !reflect.DeepEqual(m.Calls(), expected) {
+		t.Errorf("Wrong calls recorded. Got %v, expected %v", m.Calls(), expected)
+	}
+}
+
+func TestClientNotStubbed(t *testing.T) {
+	m := &Client{}
+	if _, err := m.GetProvinces(); err != ErrNotStubbed {
+		t.Errorf("Expected ErrNotStubbed. Got %v", err)
+	}
+	if _, err := m.GetCity("5", "39"); err != ErrNotStubbed {
+		t.Errorf("Expected ErrNotStubbed. Got %v", err)
+	}
+	if _, err := m.GetSubdistricts("39"); err != ErrNotStubbed {
+		t.Errorf("Expected ErrNotStubbed. Got %v", err)
+	}
+	if _, err := m.QuoteShipment(context.Background(), rajaongkir.Shipment{}); err != ErrNotStubbed {
+		t.Errorf("Expected ErrNotStubbed. Got %v", err)
+	}
+}
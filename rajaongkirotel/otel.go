@@ -0,0 +1,70 @@
+// Package rajaongkirotel traces the calls made by a rajaongkir client
+// with OpenTelemetry.
+//
+//	r := rajaongkir.New(apiKey, baseURL, nil,
+//		rajaongkir.WithTracer(rajaongkirotel.NewTracer(otel.GetTracerProvider())))
+package rajaongkirotel
+
+import (
+	"context"
+	"fmt"
+
+	rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/GreenGeorge/go-rajaongkir"
+
+// Tracer implements rajaongkir.Tracer on top of an OpenTelemetry tracer
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+var _ rajaongkir.Tracer = (*Tracer)(nil)
+
+// NewTracer creates a Tracer that starts spans from tp
+func NewTracer(tp trace.TracerProvider) *Tracer {
+	return &Tracer{tracer: tp.Tracer(instrumentationName)}
+}
+
+// Start begins a client span as a child of any span in ctx
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, rajaongkir.Span) {
+	ctx, s := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, &span{s}
+}
+
+type span struct {
+	span trace.Span
+}
+
+func (s *span) SetAttribute(key string, value interface{}) {
+	s.span.SetAttributes(toAttribute(key, value))
+}
+
+func (s *span) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s *span) End() {
+	s.span.End()
+}
+
+func toAttribute(key string, value interface{}) attribute.KeyValue {
+	switch v := value.(type) {
+	case string:
+		return attribute.String(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	case float64:
+		return attribute.Float64(key, v)
+	default:
+		return attribute.String(key, fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,60 @@
+package rajaongkirotel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracer(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	tracer := NewTracer(tp)
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "checkout")
+	_, span := tracer.Start(ctx, "rajaongkir.GetCost")
+	span.SetAttribute("rajaongkir.courier", "jne")
+	span.SetAttribute("rajaongkir.weight", 1700)
+	span.SetAttribute("rajaongkir.cache_hit", false)
+	span.RecordError(errors.New("Invalid key"))
+	span.End()
+	parent.End()
+
+	spans := rec.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("Wrong number of spans. Got %d, expected 2", len(spans))
+	}
+	s := spans[0]
+	if s.Name() != "rajaongkir.GetCost" {
+		t.Errorf("Wrong span name. Got %s, expected rajaongkir.GetCost", s.Name())
+	}
+	if s.SpanKind() != trace.SpanKindClient {
+		t.Errorf("Wrong span kind. Got %s, expected client", s.SpanKind())
+	}
+	if s.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("Span not propagated from the caller's context")
+	}
+	if s.Status().Code != codes.Error {
+		t.Errorf("Wrong status. Got %s, expected error", s.Status().Code)
+	}
+	expected := []attribute.KeyValue{
+		attribute.String("rajaongkir.courier", "jne"),
+		attribute.Int("rajaongkir.weight", 1700),
+		attribute.Bool("rajaongkir.cache_hit", false),
+	}
+	attrs := s.Attributes()
+	if len(attrs) != len(expected) {
+		t.Fatalf("Wrong attributes. Got %v, expected %v", attrs, expected)
+	}
+	for i := range expected {
+		if attrs[i] != expected[i] {
+			t.Errorf("Wrong attribute. Got %v, expected %v", attrs[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Package rajaongkirprom exposes the calls made by a rajaongkir client
+// as Prometheus metrics.
+//
+//	c := rajaongkirprom.New("shop")
+//	prometheus.MustRegister(c)
+//	r := rajaongkir.New(apiKey, baseURL, nil, rajaongkir.WithMetrics(c))
+package rajaongkirprom
+
+import (
+	"strconv"
+	"time"
+
+	rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const subsystem = "rajaongkir"
+
+// Collector implements both rajaongkir.Metrics and prometheus.Collector
+type Collector struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	hedges   *prometheus.CounterVec
+	retries  *prometheus.CounterVec
+	cache    *prometheus.CounterVec
+}
+
+var (
+	_ rajaongkir.Metrics      = (*Collector)(nil)
+	_ rajaongkir.HedgeMetrics = (*Collector)(nil)
+	_ rajaongkir.RetryMetrics = (*Collector)(nil)
+	_ rajaongkir.CacheMetrics = (*Collector)(nil)
+	_ prometheus.Collector    = (*Collector)(nil)
+)
+
+// New creates a Collector whose metrics are prefixed with namespace
+func New(namespace string) *Collector {
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "requests_total",
+			Help:      "Number of calls made to the RajaOngkir API.",
+		}, []string{"endpoint"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "errors_total",
+			Help:      "Number of failed calls to the RajaOngkir API by RajaOngkir status code, 0 when no response was decoded.",
+		}, []string{"endpoint", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of calls to the RajaOngkir API.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"endpoint"}),
+		hedges: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "hedges_total",
+			Help:      "Number of hedged calls to the RajaOngkir API by whether the hedge answered first.",
+		}, []string{"endpoint", "won"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "retries_total",
+			Help:      "Number of calls to the RajaOngkir API retried with another key after the previous one was rejected.",
+		}, []string{"endpoint"}),
+		cache: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "cache_lookups_total",
+			Help:      "Number of lookups of the proxy and gRPC caches by whether they were served from the cache.",
+		}, []string{"endpoint", "hit"}),
+	}
+}
+
+// ObserveRequest records a finished call
+func (c *Collector) ObserveRequest(endpoint string, status int, duration time.Duration, err error) {
+	c.requests.WithLabelValues(endpoint).Inc()
+	c.duration.WithLabelValues(endpoint).Observe(duration.Seconds())
+	if err != nil || status < 200 || status >= 300 {
+		c.errors.WithLabelValues(endpoint, strconv.Itoa(status)).Inc()
+	}
+}
+
+// ObserveHedge records a hedged call
+func (c *Collector) ObserveHedge(endpoint string, won bool) {
+	c.hedges.WithLabelValues(endpoint, strconv.FormatBool(won)).Inc()
+}
+
+// ObserveRetry records a call retried with another key
+func (c *Collector) ObserveRetry(endpoint string) {
+	c.retries.WithLabelValues(endpoint).Inc()
+}
+
+// ObserveCache records a cache lookup
+func (c *Collector) ObserveCache(endpoint string, hit bool) {
+	c.cache.WithLabelValues(endpoint, strconv.FormatBool(hit)).Inc()
+}
+
+// Describe sends the descriptors of all metrics to ch
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.errors.Describe(ch)
+	c.duration.Describe(ch)
+	c.hedges.Describe(ch)
+	c.retries.Describe(ch)
+	c.cache.Describe(ch)
+}
+
+// Collect sends the current value of all metrics to ch
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.errors.Collect(ch)
+	c.duration.Collect(ch)
+	c.hedges.Collect(ch)
+	c.retries.Collect(ch)
+	c.cache.Collect(ch)
+}
@@ -0,0 +1,98 @@
+package rajaongkirprom
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	c := New("test")
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(c); err != nil {
+		t.Fatalf("Register failed: %s", err)
+	}
+
+	c.ObserveRequest("/cost", 200, time.Millisecond*20, nil)
+	c.ObserveRequest("/cost", 400, time.Millisecond*30, nil)
+	c.ObserveRequest("/city", 0, time.Second, errors.New("timeout"))
+
+	expected := `
+# HELP test_rajaongkir_errors_total Number of failed calls to the RajaOngkir API by RajaOngkir status code, 0 when no response was decoded.
+# TYPE test_rajaongkir_errors_total counter
+test_rajaongkir_errors_total{endpoint="/city",status="0"} 1
+test_rajaongkir_errors_total{endpoint="/cost",status="400"} 1
+# HELP test_rajaongkir_requests_total Number of calls made to the RajaOngkir API.
+# TYPE test_rajaongkir_requests_total counter
+test_rajaongkir_requests_total{endpoint="/city"} 1
+test_rajaongkir_requests_total{endpoint="/cost"} 2
+`
+	err := testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"test_rajaongkir_requests_total", "test_rajaongkir_errors_total")
+	if err != nil {
+		t.Error(err)
+	}
+
+	count := testutil.CollectAndCount(c, "test_rajaongkir_request_duration_seconds")
+	if count != 2 {
+		t.Errorf("Wrong number of histograms. Got %d, expected 2", count)
+	}
+}
+
+func TestCollectorHedges(t *testing.T) {
+	c := New("test")
+	c.ObserveHedge("/cost", true)
+	c.ObserveHedge("/cost", true)
+	c.ObserveHedge("/cost", false)
+
+	expected := `
+# HELP test_rajaongkir_hedges_total Number of hedged calls to the RajaOngkir API by whether the hedge answered first.
+# TYPE test_rajaongkir_hedges_total counter
+test_rajaongkir_hedges_total{endpoint="/cost",won="false"} 1
+test_rajaongkir_hedges_total{endpoint="/cost",won="true"} 2
+`
+	err := testutil.CollectAndCompare(c, strings.NewReader(expected), "test_rajaongkir_hedges_total")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCollectorRetries(t *testing.T) {
+	c := New("test")
+	c.ObserveRetry("/cost")
+	c.ObserveRetry("/cost")
+	c.ObserveRetry("/province")
+
+	expected := `
+# HELP test_rajaongkir_retries_total Number of calls to the RajaOngkir API retried with another key after the previous one was rejected.
+# TYPE test_rajaongkir_retries_total counter
+test_rajaongkir_retries_total{endpoint="/cost"} 2
+test_rajaongkir_retries_total{endpoint="/province"} 1
+`
+	err := testutil.CollectAndCompare(c, strings.NewReader(expected), "test_rajaongkir_retries_total")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCollectorCache(t *testing.T) {
+	c := New("test")
+	c.ObserveCache("/rates", false)
+	c.ObserveCache("/rates", true)
+	c.ObserveCache("/rates", true)
+
+	expected := `
+# HELP test_rajaongkir_cache_lookups_total Number of lookups of the proxy and gRPC caches by whether they were served from the cache.
+# TYPE test_rajaongkir_cache_lookups_total counter
+test_rajaongkir_cache_lookups_total{endpoint="/rates",hit="false"} 1
+test_rajaongkir_cache_lookups_total{endpoint="/rates",hit="true"} 2
+`
+	err := testutil.CollectAndCompare(c, strings.NewReader(expected), "test_rajaongkir_cache_lookups_total")
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,64 @@
+package rajaongkirproxy
+
+import (
+	"sync"
+	"time"
+)
+
+// maxClients bounds the memory used by the limiter
+const maxClients = 10000
+
+// bucket is a token bucket for one client
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// clientLimiter rate limits each client with its own token bucket
+type clientLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   int
+	now     func() time.Time
+	buckets map[string]*bucket
+}
+
+func newClientLimiter(rate float64, burst int) *clientLimiter {
+	return &clientLimiter{rate: rate, burst: burst, now: time.Now, buckets: map[string]*bucket{}}
+}
+
+// allow takes a token for client. If none is left it returns
+// how long until the next one is available
+func (l *clientLimiter) allow(client string) (time.Duration, bool) {
+	if l.rate <= 0 {
+		return 0, true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	b, ok := l.buckets[client]
+	if !ok {
+		if len(l.buckets) >= maxClients {
+			l.prune(now)
+		}
+		b = &bucket{tokens: float64(l.burst), last: now}
+		l.buckets[client] = b
+	}
+	b.tokens = min(float64(l.burst), b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / l.rate * float64(time.Second)), false
+	}
+	b.tokens--
+	return 0, true
+}
+
+// prune forgets clients whose buckets have refilled, as a new bucket would be the same
+func (l *clientLimiter) prune(now time.Time) {
+	full := time.Duration(float64(l.burst) / l.rate * float64(time.Second))
+	for client, b := range l.buckets {
+		if now.Sub(b.last) >= full {
+			delete(l.buckets, client)
+		}
+	}
+}
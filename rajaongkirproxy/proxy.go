@@ -0,0 +1,296 @@
+// Package rajaongkirproxy serves a small JSON shipping API backed by a rajaongkir.Client,
+// so browsers and apps can look up rates without ever seeing the API key.
+//
+//	GET /provinces
+//	GET /cities?province=5
+//	GET /rates?origin=501&destination=114&weight=1700&courier=jne:pos
+//
+// Successful responses are cached, cross-origin requests are allowed
+// only from configured origins and each client is rate limited.
+// Requests RajaOngkir rejects, such as an unknown courier or a weight over the tier limit,
+// fail with 400; a rejected API key or an unreachable RajaOngkir fails with 502.
+//
+//	r := rajaongkir.New(apiKey, baseURL, nil)
+//	h := rajaongkirproxy.NewHandler(r, rajaongkirproxy.WithAllowedOrigins("https://shop.example.com"))
+//	http.ListenAndServe(":8080", h)
+package rajaongkirproxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+	"github.com/GreenGeorge/go-rajaongkir/internal/ttlcache"
+)
+
+// Rate is one service quoted by GET /rates
+type Rate struct {
+	Courier     string `json:"courier"`
+	Service     string `json:"service"`
+	Description string `json:"description"`
+	Cost        int    `json:"cost"`
+	ETD         string `json:"etd"`
+	Note        string `json:"note,omitempty"`
+	Estimated   bool   `json:"estimated,omitempty"`
+}
+
+// Handler is an http.Handler serving the proxy API
+type Handler struct {
+	client   rajaongkir.Client
+	cache    *ttlcache.Cache[response]
+	origins  map[string]bool
+	limiter  *clientLimiter
+	clientID func(*http.Request) string
+	mux      *http.ServeMux
+}
+
+// Option configures a Handler
+type Option func(*Handler)
+
+// WithCacheTTL sets how long successful responses are cached. Defaults to 10 minutes,
+// 0 disables caching
+func WithCacheTTL(d time.Duration) Option {
+	return func(h *Handler) {
+		h.cache.TTL = d
+	}
+}
+
+// WithMetrics tells m whether each lookup was served from the cache
+func WithMetrics(m rajaongkir.CacheMetrics) Option {
+	return func(h *Handler) {
+		h.cache.Metrics = m
+	}
+}
+
+// WithTracer traces each lookup with t, in a span telling whether it was served from the cache.
+// Give the client the same tracer to see its calls as children of that span
+func WithTracer(t rajaongkir.Tracer) Option {
+	return func(h *Handler) {
+		h.cache.Tracer = t
+	}
+}
+
+// WithAllowedOrigins sets the origins allowed to make cross-origin requests.
+// "*" allows any origin. By default cross-origin requests are not allowed
+func WithAllowedOrigins(origins ...string) Option {
+	return func(h *Handler) {
+		h.origins = map[string]bool{}
+		for _, o := range origins {
+			h.origins[o] = true
+		}
+	}
+}
+
+// WithRateLimit allows each client perSecond requests on average, in bursts of up to burst.
+// Defaults to 5 per second in bursts of 10, a perSecond of 0 disables limiting
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(h *Handler) {
+		h.limiter.rate = perSecond
+		h.limiter.burst = burst
+	}
+}
+
+// WithClientID sets how clients are told apart for rate limiting.
+// Defaults to the remote IP address; set it to read X-Forwarded-For
+// or an API token when running behind a load balancer
+func WithClientID(f func(*http.Request) string) Option {
+	return func(h *Handler) {
+		h.clientID = f
+	}
+}
+
+// NewHandler returns a Handler serving lookups with c
+func NewHandler(c rajaongkir.Client, opts ...Option) *Handler {
+	h := &Handler{
+		client:   c,
+		cache:    ttlcache.New[response](time.Minute * 10),
+		origins:  map[string]bool{},
+		limiter:  newClientLimiter(5, 10),
+		clientID: remoteIP,
+		mux:      http.NewServeMux(),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.mux.HandleFunc("GET /provinces", h.cached(h.provinces))
+	h.mux.HandleFunc("GET /cities", h.cached(h.cities))
+	h.mux.HandleFunc("GET /rates", h.cached(h.rates))
+	return h
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// ServeHTTP applies CORS and rate limiting, then serves the request
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if !h.origins["*"] && !h.origins[origin] {
+			writeError(w, http.StatusForbidden, "origin not allowed")
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+			w.Header().Set("Access-Control-Max-Age", "86400")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	if wait, ok := h.limiter.allow(h.clientID(r)); !ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		writeError(w, http.StatusTooManyRequests, "too many requests")
+		return
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+// badRequest is an error caused by the caller's parameters
+type badRequest string
+
+func (e badRequest) Error() string { return string(e) }
+
+// response is a JSON body as cached
+type response struct {
+	body      []byte
+	estimated bool
+}
+
+func (r response) Estimated() bool { return r.estimated }
+
+// encodeError is returned when a result cannot be encoded as JSON
+type encodeError struct{ error }
+
+// cached serves the result of f as JSON, caching it by URL
+func (h *Handler) cached(f func(r *http.Request) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Path + "?" + r.URL.Query().Encode()
+		res, err := h.cache.Load(r.Context(), r.URL.Path, key, func(ctx context.Context) (response, error) {
+			v, err := f(r.WithContext(ctx))
+			if err != nil {
+				return response{}, err
+			}
+			body, err := json.Marshal(v)
+			if err != nil {
+				return response{}, encodeError{err}
+			}
+			e, ok := v.(ttlcache.Estimated)
+			return response{body: body, estimated: ok && e.Estimated()}, nil
+		})
+		var br badRequest
+		var ee encodeError
+		switch {
+		case errors.As(err, &br), rejectsRequest(err):
+			writeError(w, http.StatusBadRequest, err.Error())
+		case errors.As(err, &ee):
+			writeError(w, http.StatusInternalServerError, err.Error())
+		case errors.Is(err, rajaongkir.ErrCircuitOpen):
+			writeError(w, http.StatusServiceUnavailable, err.Error())
+		case err != nil:
+			writeError(w, http.StatusBadGateway, err.Error())
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(res.body)
+		}
+	}
+}
+
+// rejectsRequest reports whether err blames the parameters of the request,
+// as opposed to the API key or RajaOngkir being unavailable
+func rejectsRequest(err error) bool {
+	var statusErr *rajaongkir.StatusError
+	switch {
+	case errors.Is(err, rajaongkir.ErrInvalidWeight), errors.Is(err, rajaongkir.ErrWeightLimit):
+		return true
+	case errors.As(err, &statusErr):
+		return statusErr.Code == http.StatusBadRequest && !statusErr.KeyRejected()
+	}
+	return false
+}
+
+// rates are the rates answering GET /rates
+type rates []Rate
+
+// Estimated reports whether any rate was served by a rajaongkir.Fallback
+func (rs rates) Estimated() bool {
+	for _, rate := range rs {
+		if rate.Estimated {
+			return true
+		}
+	}
+	return false
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+func (h *Handler) provinces(r *http.Request) (any, error) {
+	return h.client.GetProvincesContext(r.Context())
+}
+
+func (h *Handler) cities(r *http.Request) (any, error) {
+	province := r.URL.Query().Get("province")
+	if province == "" {
+		return h.client.GetCitiesContext(r.Context())
+	}
+	if _, err := rajaongkir.ParseProvinceID(province); err != nil {
+		return nil, badRequest(err.Error())
+	}
+	return h.client.GetCitiesInProvinceContext(r.Context(), province)
+}
+
+func (h *Handler) rates(r *http.Request) (any, error) {
+	q := r.URL.Query()
+	origin, err := rajaongkir.ParseCityID(q.Get("origin"))
+	if err != nil {
+		return nil, badRequest("origin: " + err.Error())
+	}
+	destination, err := rajaongkir.ParseCityID(q.Get("destination"))
+	if err != nil {
+		return nil, badRequest("destination: " + err.Error())
+	}
+	weight, err := strconv.Atoi(q.Get("weight"))
+	if err != nil || weight <= 0 {
+		return nil, badRequest("weight must be a positive number of grams")
+	}
+	if q.Get("courier") == "" {
+		return nil, badRequest("courier is required")
+	}
+
+	rs := rates{}
+	for _, courier := range strings.Split(q.Get("courier"), ":") {
+		costs, err := h.client.GetCostContext(r.Context(), string(origin), string(destination), weight, courier)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range costs {
+			for _, detail := range c.Cost {
+				rs = append(rs, Rate{
+					Courier:     courier,
+					Service:     c.Service,
+					Description: c.Description,
+					Cost:        detail.Value,
+					ETD:         detail.ETD,
+					Note:        detail.Note,
+					Estimated:   c.Estimated,
+				})
+			}
+		}
+	}
+	return rs, nil
+}
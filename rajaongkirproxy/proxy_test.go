@@ -0,0 +1,281 @@
+package rajaongkirproxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+	"github.com/GreenGeorge/go-rajaongkir/rajaongkirtest"
+)
+
+func get(h http.Handler, target string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandlerEndpoints(t *testing.T) {
+	srv := rajaongkirtest.NewServer()
+	defer srv.Close()
+	h := NewHandler(srv.NewClient(), WithRateLimit(0, 0))
+
+	w := get(h, "/provinces")
+	var provinces []rajaongkir.Province
+	json.Unmarshal(w.Body.Bytes(), &provinces)
+	if w.Code != http.StatusOK || len(provinces) != 34 {
+		t.Errorf("Wrong provinces. Got %d with %d provinces, expected 200 with 34", w.Code, len(provinces))
+	}
+
+	w = get(h, "/cities?province=5")
+	var cities []rajaongkir.City
+	json.Unmarshal(w.Body.Bytes(), &cities)
+	if w.Code != http.StatusOK || len(cities) != 5 {
+		t.Errorf("Wrong cities. Got %d with %d cities, expected 200 with 5", w.Code, len(cities))
+	}
+
+	w = get(h, "/rates?origin=501&destination=114&weight=1700&courier=jne")
+	var rates []Rate
+	json.Unmarshal(w.Body.Bytes(), &rates)
+	if w.Code != http.StatusOK || len(rates) != 3 {
+		t.Fatalf("Wrong rates. Got %d with %s, expected 200 with 3 rates", w.Code, w.Body)
+	}
+	expected := Rate{Courier: "jne", Service: "OKE", Description: "Ongkos Kirim Ekonomis", Cost: 38000, ETD: "4-5"}
+	if rates[0] != expected {
+		t.Errorf("Wrong rate. Got %+v, expected %+v", rates[0], expected)
+	}
+	if w.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("Wrong content type. Got %s, expected application/json", w.Header().Get("Content-Type"))
+	}
+}
+
+func TestHandlerErrors(t *testing.T) {
+	srv := rajaongkirtest.NewServer()
+	defer srv.Close()
+	h := NewHandler(srv.NewClient(rajaongkir.WithTier(rajaongkir.TierStarter)), WithRateLimit(0, 0), WithCacheTTL(0))
+
+	tables := []struct {
+		target string
+		fault  *rajaongkirtest.Fault
+		code   int
+	}{
+		{"/rates?origin=501&destination=114&weight=0&courier=jne", nil, http.StatusBadRequest},
+		{"/rates?origin=abc&destination=114&weight=1700&courier=jne", nil, http.StatusBadRequest},
+		{"/rates?origin=501&destination=114&weight=1700", nil, http.StatusBadRequest},
+		{"/cities?province=x", nil, http.StatusBadRequest},
+		{"/rates?origin=501&destination=114&weight=40000&courier=jne", nil, http.StatusBadRequest},
+		{"/rates?origin=501&destination=114&weight=1700&courier=foo", nil, http.StatusBadRequest},
+		{"/rates?origin=99999&destination=114&weight=1700&courier=jne", nil, http.StatusBadRequest},
+		{"/rates?origin=501&destination=114&weight=1700&courier=jne", &rajaongkirtest.Fault{Code: 400, Description: "Invalid key. API key tidak ditemukan di database RajaOngkir."}, http.StatusBadGateway},
+		{"/rates?origin=501&destination=114&weight=1700&courier=jne", &rajaongkirtest.Fault{Code: 400, Description: "Daily limit exceeded."}, http.StatusBadGateway},
+		{"/rates?origin=501&destination=114&weight=1700&courier=jne", &rajaongkirtest.Fault{HTTPStatus: http.StatusBadGateway, Body: "<html>502 Bad Gateway</html>"}, http.StatusBadGateway},
+		{"/subdistricts", nil, http.StatusNotFound},
+	}
+
+	for _, table := range tables {
+		if table.fault != nil {
+			srv.FailNext("/cost", *table.fault)
+		}
+		w := get(h, table.target)
+		if w.Code != table.code {
+			t.Errorf("Wrong status for %s. Got %d (%s), expected %d", table.target, w.Code, w.Body, table.code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/provinces", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Wrong status for POST. Got %d, expected 405", w.Code)
+	}
+}
+
+func TestHandlerCache(t *testing.T) {
+	srv := rajaongkirtest.NewServer()
+	defer srv.Close()
+	h := NewHandler(srv.NewClient(), WithRateLimit(0, 0), WithCacheTTL(time.Minute))
+	now := time.Now()
+	h.cache.Now = func() time.Time { return now }
+
+	get(h, "/rates?origin=501&destination=114&weight=1700&courier=jne")
+	get(h, "/rates?courier=jne&weight=1700&destination=114&origin=501")
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("Wrong number of upstream requests. Got %d, expected 1", n)
+	}
+
+	srv.FailNext("/province", rajaongkirtest.Fault{Code: 400, Description: "Daily limit exceeded"})
+	get(h, "/provinces")
+	get(h, "/provinces")
+	if n := len(srv.Requests()); n != 3 {
+		t.Errorf("Expected errors not to be cached. Got %d upstream requests, expected 3", n)
+	}
+
+	srv.FailNext("/city", rajaongkirtest.Fault{Code: 400, Description: "Daily limit exceeded"})
+	if w := get(h, "/cities?province=5"); w.Code != http.StatusBadGateway {
+		t.Errorf("Wrong status for a rejected key. Got %d (%s), expected 502", w.Code, w.Body)
+	}
+	get(h, "/cities?province=5")
+	if n := len(srv.Requests()); n != 5 {
+		t.Errorf("Expected rejected city lookups not to be cached. Got %d upstream requests, expected 5", n)
+	}
+
+	now = now.Add(time.Minute)
+	get(h, "/rates?origin=501&destination=114&weight=1700&courier=jne")
+	if n := len(srv.Requests()); n != 6 {
+		t.Errorf("Expected the cache to expire. Got %d upstream requests, expected 6", n)
+	}
+}
+
+func TestHandlerCacheEstimates(t *testing.T) {
+	srv := rajaongkirtest.NewServer()
+	defer srv.Close()
+	store, _ := rajaongkir.NewQuoteStore("")
+	h := NewHandler(srv.NewClient(rajaongkir.WithFallback(store)), WithRateLimit(0, 0), WithCacheTTL(time.Minute))
+	now := time.Now()
+	h.cache.Now = func() time.Time { return now }
+	target := "/rates?origin=501&destination=114&weight=1700&courier=jne"
+
+	get(h, target)
+	now = now.Add(time.Minute)
+	srv.FailNext("/cost", rajaongkirtest.Fault{HTTPStatus: http.StatusBadGateway, Body: "<html>502 Bad Gateway</html>"})
+	var rates []Rate
+	json.Unmarshal(get(h, target).Body.Bytes(), &rates)
+	if len(rates) == 0 || !rates[0].Estimated {
+		t.Fatalf("Expected estimated rates during the outage. Got %+v", rates)
+	}
+
+	rates = nil
+	json.Unmarshal(get(h, target).Body.Bytes(), &rates)
+	if len(rates) == 0 || rates[0].Estimated {
+		t.Errorf("Expected real rates once the API recovered. Got %+v", rates)
+	}
+	if n := len(srv.Requests()); n != 3 {
+		t.Errorf("Expected estimates not to be cached. Got %d upstream requests, expected 3", n)
+	}
+}
+
+type fakeCacheMetrics struct {
+	lookups []string
+}
+
+func (m *fakeCacheMetrics) ObserveCache(endpoint string, hit bool) {
+	m.lookups = append(m.lookups, fmt.Sprintf("%s %t", endpoint, hit))
+}
+
+type fakeSpan struct {
+	name       string
+	attributes map[string]interface{}
+}
+
+func (s *fakeSpan) SetAttribute(key string, value interface{}) { s.attributes[key] = value }
+func (s *fakeSpan) RecordError(error)                          {}
+func (s *fakeSpan) End()                                       {}
+
+type fakeTracer struct {
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string) (context.Context, rajaongkir.Span) {
+	span := &fakeSpan{name: name, attributes: map[string]interface{}{}}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func TestHandlerCacheObserved(t *testing.T) {
+	srv := rajaongkirtest.NewServer()
+	defer srv.Close()
+	m := &fakeCacheMetrics{}
+	tracer := &fakeTracer{}
+	h := NewHandler(srv.NewClient(rajaongkir.WithTracer(tracer)), WithRateLimit(0, 0), WithMetrics(m), WithTracer(tracer))
+
+	get(h, "/provinces")
+	get(h, "/provinces")
+	if got := strings.Join(m.lookups, ", "); got != "/provinces false, /provinces true" {
+		t.Errorf("Wrong cache lookups. Got %s, expected a miss then a hit", got)
+	}
+
+	names := []string{}
+	for _, span := range tracer.spans {
+		names = append(names, span.name)
+	}
+	if got := strings.Join(names, " "); got != "rajaongkir.Cache rajaongkir.GetProvinces rajaongkir.Cache" {
+		t.Fatalf("Wrong spans. Got %s, expected the client call only on the miss", got)
+	}
+	for i, hit := range map[int]bool{0: false, 2: true} {
+		span := tracer.spans[i]
+		if span.attributes["rajaongkir.cache_hit"] != hit || span.attributes["rajaongkir.endpoint"] != "/provinces" {
+			t.Errorf("Wrong attributes for lookup %d. Got %v, expected cache_hit %t", i, span.attributes, hit)
+		}
+	}
+}
+
+func TestHandlerCORS(t *testing.T) {
+	srv := rajaongkirtest.NewServer()
+	defer srv.Close()
+	h := NewHandler(srv.NewClient(), WithAllowedOrigins("https://shop.example.com"))
+
+	w := get(h, "/provinces", "Origin", "https://shop.example.com")
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "https://shop.example.com" {
+		t.Errorf("Expected the origin to be allowed. Got %d %v", w.Code, w.Header())
+	}
+
+	w = get(h, "/provinces", "Origin", "https://evil.example.com")
+	if w.Code != http.StatusForbidden || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("Expected the origin to be rejected. Got %d %v", w.Code, w.Header())
+	}
+
+	req := httptest.NewRequest(http.MethodOptions, "/rates", nil)
+	req.Header.Set("Origin", "https://shop.example.com")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent || w.Header().Get("Access-Control-Allow-Methods") == "" {
+		t.Errorf("Wrong preflight response. Got %d %v", w.Code, w.Header())
+	}
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("Wrong number of upstream requests. Got %d, expected 1", n)
+	}
+}
+
+func TestHandlerRateLimit(t *testing.T) {
+	srv := rajaongkirtest.NewServer()
+	defer srv.Close()
+	h := NewHandler(srv.NewClient(), WithRateLimit(1, 2))
+	now := time.Now()
+	h.limiter.now = func() time.Time { return now }
+
+	tables := []struct {
+		client  string
+		advance time.Duration
+		code    int
+	}{
+		{"10.0.0.1:1234", 0, http.StatusOK},
+		{"10.0.0.1:1235", 0, http.StatusOK},
+		{"10.0.0.1:1236", 0, http.StatusTooManyRequests},
+		{"10.0.0.2:1234", 0, http.StatusOK},
+		{"10.0.0.1:1237", time.Second, http.StatusOK},
+		{"10.0.0.1:1238", 0, http.StatusTooManyRequests},
+	}
+
+	for i, table := range tables {
+		now = now.Add(table.advance)
+		req := httptest.NewRequest(http.MethodGet, "/provinces", nil)
+		req.RemoteAddr = table.client
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != table.code {
+			t.Errorf("Wrong status for request %d from %s. Got %d, expected %d", i, table.client, w.Code, table.code)
+		}
+		if w.Code == http.StatusTooManyRequests && w.Header().Get("Retry-After") != "1" {
+			t.Errorf("Wrong Retry-After. Got %q, expected 1", w.Header().Get("Retry-After"))
+		}
+	}
+}
@@ -0,0 +1,230 @@
+// Package rajaongkirreplay records RajaOngkir API calls into golden files
+// and replays them, so tests can run offline against real payloads.
+//
+// Record once against the live API:
+//
+//	rec := rajaongkirreplay.NewRecorder("testdata", nil)
+//	r := rajaongkir.New(apiKey, baseURL, &http.Client{Transport: rec})
+//
+// Then replay in tests:
+//
+//	r := rajaongkir.New("any", baseURL, &http.Client{Transport: rajaongkirreplay.NewReplayer("testdata")})
+//
+// The key header is never written to disk.
+package rajaongkirreplay
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const redacted = "REDACTED"
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of a request that identifies it
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response as received
+type RecordedResponse struct {
+	StatusCode int             `json:"status_code"`
+	Header     http.Header     `json:"header,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`
+	// RawBody holds bodies that are not JSON, such as HTML error pages
+	RawBody string `json:"raw_body,omitempty"`
+}
+
+var unsafeChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Name returns the golden file name for a request
+// given its method, path with query string and body
+func Name(method, path, body string) string {
+	sum := sha1.Sum([]byte(method + " " + path + "\n" + body))
+	endpoint, _, _ := strings.Cut(path, "?")
+	endpoint = endpoint[strings.LastIndex(endpoint, "/")+1:]
+	slug := strings.Trim(unsafeChars.ReplaceAllString(strings.ToLower(method+"_"+endpoint), "_"), "_")
+	return fmt.Sprintf("%s_%s.json", slug, hex.EncodeToString(sum[:])[:10])
+}
+
+// pathOf returns the path and query string of req
+func pathOf(req *http.Request) string {
+	return req.URL.RequestURI()
+}
+
+func readRequestBody(req *http.Request) (string, error) {
+	if req.Body == nil {
+		return "", nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return string(body), nil
+}
+
+func scrub(h http.Header) http.Header {
+	h = h.Clone()
+	for k := range h {
+		if strings.EqualFold(k, "key") {
+			h[k] = []string{redacted}
+		}
+	}
+	return h
+}
+
+// Recorder is an http.RoundTripper that saves every interaction
+// passing through it as a golden file in Dir
+type Recorder struct {
+	Dir       string
+	Transport http.RoundTripper
+	// OnShapeChange, if set, is called when a response has a different
+	// JSON shape than the golden file it is about to replace
+	OnShapeChange func(name string, diffs []string)
+}
+
+// NewRecorder creates a Recorder writing to dir.
+// A nil transport uses http.DefaultTransport
+func NewRecorder(dir string, transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{Dir: dir, Transport: transport}
+}
+
+// RoundTrip sends req and records the interaction
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	in := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    pathOf(req),
+			Header: scrub(req.Header),
+			Body:   reqBody,
+		},
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+			Header:     scrub(res.Header),
+		},
+	}
+	if json.Valid(resBody) {
+		in.Response.Body = json.RawMessage(resBody)
+	} else {
+		in.Response.RawBody = string(resBody)
+	}
+	if err := r.save(Name(req.Method, pathOf(req), reqBody), in); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Recorder) save(name string, in Interaction) error {
+	path := filepath.Join(r.Dir, name)
+	if r.OnShapeChange != nil && in.Response.Body != nil {
+		if old, err := Load(path); err == nil && old.Response.Body != nil {
+			diffs, err := CompareShape(old.Response.Body, in.Response.Body)
+			if err != nil {
+				return err
+			}
+			if len(diffs) > 0 {
+				r.OnShapeChange(name, diffs)
+			}
+		}
+	}
+	data, err := json.MarshalIndent(in, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(r.Dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Load reads a golden file
+func Load(path string) (Interaction, error) {
+	in := Interaction{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return in, err
+	}
+	err = json.Unmarshal(data, &in)
+	return in, err
+}
+
+// Replayer is an http.RoundTripper that answers requests
+// from the golden files in Dir without touching the network
+type Replayer struct {
+	Dir string
+}
+
+// NewReplayer creates a Replayer reading from dir
+func NewReplayer(dir string) *Replayer {
+	return &Replayer{Dir: dir}
+}
+
+// RoundTrip returns the recorded response for req,
+// or an error if none was recorded
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	name := Name(req.Method, pathOf(req), body)
+	in, err := Load(filepath.Join(r.Dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("rajaongkirreplay: no recording of %s %s: %w", req.Method, pathOf(req), err)
+	}
+	resBody := []byte(in.Response.Body)
+	if in.Response.Body == nil {
+		resBody = []byte(in.Response.RawBody)
+	}
+	header := in.Response.Header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+		StatusCode:    in.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(resBody)),
+		ContentLength: int64(len(resBody)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,134 @@
+package rajaongkirreplay
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+	"github.com/GreenGeorge/go-rajaongkir/rajaongkirtest"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	srv := rajaongkirtest.NewServer()
+	baseURL := srv.BaseURL()
+
+	rec := NewRecorder(dir, srv.Client().Transport)
+	ro := rajaongkir.New(rajaongkirtest.DefaultKey, baseURL, &http.Client{Transport: rec})
+	recordedCosts, err := ro.GetCost("501", "114", 1700, "jne")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	recordedCity, err := ro.GetCity("5", "39")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	srv.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 2 {
+		t.Fatalf("Wrong number of golden files. Got %d, expected 2", len(files))
+	}
+	for _, f := range files {
+		data, _ := os.ReadFile(f)
+		if strings.Contains(string(data), rajaongkirtest.DefaultKey) {
+			t.Errorf("API key not scrubbed from %s", f)
+		}
+	}
+
+	ro = rajaongkir.New("ANOTHERKEY", baseURL, &http.Client{Transport: NewReplayer(dir)})
+	costs, err := ro.GetCost("501", "114", 1700, "jne")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(costs, recordedCosts) {
+		t.Errorf("Wrong costs replayed. Got %v, expected %v", costs, recordedCosts)
+	}
+	city, err := ro.GetCity("5", "39")
+	if err != nil || city != recordedCity {
+		t.Errorf("Wrong city replayed. Got %v (%v), expected %v", city, err, recordedCity)
+	}
+
+	_, err = ro.GetCost("501", "114", 2000, "jne")
+	if err == nil || !strings.Contains(err.Error(), "no recording") {
+		t.Errorf("Expected a missing recording error. Got %v", err)
+	}
+}
+
+func TestRecordShapeChange(t *testing.T) {
+	dir := t.TempDir()
+	srv := rajaongkirtest.NewServer()
+	defer srv.Close()
+
+	var changed []string
+	rec := NewRecorder(dir, srv.Client().Transport)
+	rec.OnShapeChange = func(name string, diffs []string) {
+		changed = append(changed, diffs...)
+	}
+	ro := rajaongkir.New(rajaongkirtest.DefaultKey, srv.BaseURL(), &http.Client{Transport: rec})
+
+	ro.GetProvince("5")
+	if len(changed) != 0 {
+		t.Errorf("Unexpected shape change on first recording: %v", changed)
+	}
+
+	srv.FailNext("/province", rajaongkirtest.Fault{HTTPStatus: 200, Body: `{"rajaongkir":{"status":{"code":"200"},"results":{}}}`})
+	ro.GetProvince("5")
+	expected := []string{
+		"rajaongkir.query: missing",
+		"rajaongkir.status.code: number became string",
+		"rajaongkir.status.description: missing",
+		"rajaongkir.results.province: missing",
+		"rajaongkir.results.province_id: missing",
+	}
+	for _, e := range expected {
+		found := false
+		for _, c := range changed {
+			found = found || c == e
+		}
+		if !found {
+			t.Errorf("Shape change %q not reported. Got %v", e, changed)
+		}
+	}
+}
+
+func TestCompareShape(t *testing.T) {
+	tables := []struct {
+		a        string
+		b        string
+		expected []string
+	}{
+		{`{"a":1,"b":[{"c":"x"}]}`, `{"a":2,"b":[{"c":"y"},{"c":"z"}]}`, []string{}},
+		{`{"a":1}`, `{"a":1,"b":true}`, []string{"b: added"}},
+		{`{"a":[{"c":"x"}]}`, `{"a":[{"c":1}]}`, []string{"a[].c: string became number"}},
+		{`{"a":{"b":null}}`, `{"a":{"b":"x"}}`, []string{}},
+	}
+
+	for _, table := range tables {
+		diffs, err := CompareShape([]byte(table.a), []byte(table.b))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(diffs, table.expected) {
+			t.Errorf("Wrong diffs for %s -> %s. Got %v, expected %v", table.a, table.b, diffs, table.expected)
+		}
+	}
+}
+
+func TestName(t *testing.T) {
+	a := Name("POST", "/starter/cost", "origin=501")
+	b := Name("POST", "/starter/cost", "origin=502")
+	if a == b {
+		t.Errorf("Expected different bodies to get different names. Got %s", a)
+	}
+	if !strings.HasPrefix(a, "post_cost_") {
+		t.Errorf("Wrong name. Got %s, expected post_cost_ prefix", a)
+	}
+	if Name("GET", "/starter/city?id=39", "") != Name("GET", "/starter/city?id=39", "") {
+		t.Errorf("Expected names to be deterministic")
+	}
+}
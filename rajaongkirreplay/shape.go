@@ -0,0 +1,90 @@
+package rajaongkirreplay
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// CompareShape reports how the JSON structure of b differs from a,
+// ignoring values. Each difference names the path, e.g.
+// "rajaongkir.results[].postal_code: missing"
+func CompareShape(a, b []byte) ([]string, error) {
+	var va, vb interface{}
+	if err := json.Unmarshal(a, &va); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		return nil, err
+	}
+	diffs := []string{}
+	compare("", va, vb, &diffs)
+	return diffs, nil
+}
+
+func kind(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	default:
+		return "null"
+	}
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func compare(path string, a, b interface{}, diffs *[]string) {
+	// null carries no shape, e.g. an empty note
+	if a == nil || b == nil {
+		return
+	}
+	if kind(a) != kind(b) {
+		*diffs = append(*diffs, fmt.Sprintf("%s: %s became %s", path, kind(a), kind(b)))
+		return
+	}
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b := b.(map[string]interface{})
+		keys := []string{}
+		for k := range a {
+			keys = append(keys, k)
+		}
+		for k := range b {
+			if _, ok := a[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			va, inA := a[k]
+			vb, inB := b[k]
+			switch {
+			case !inB:
+				*diffs = append(*diffs, fmt.Sprintf("%s: missing", join(path, k)))
+			case !inA:
+				*diffs = append(*diffs, fmt.Sprintf("%s: added", join(path, k)))
+			default:
+				compare(join(path, k), va, vb, diffs)
+			}
+		}
+	case []interface{}:
+		b := b.([]interface{})
+		// Compare the first element of each, lists are assumed homogeneous
+		if len(a) > 0 && len(b) > 0 {
+			compare(path+"[]", a[0], b[0], diffs)
+		}
+	}
+}
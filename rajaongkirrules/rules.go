@@ -0,0 +1,267 @@
+// Package rajaongkirrules turns RajaOngkir quotes into the rates a customer sees
+// by applying merchant rules such as free shipping, markups and hidden services.
+//
+// Rules are applied in order to every service quoted. Each rule has conditions,
+// all of which must match, and an action. A rule that hides a service ends the
+// evaluation for that service. A rule that makes it free stops later rules from
+// changing its price, but later hide rules still run, so a slow service stays
+// hidden for an order that ships free. Every rule that changed a rate is
+// recorded in its Applied audit trail.
+//
+//	rules:
+//	  - name: free over 500k
+//	    when: {min_order_total: 500000}
+//	    then: {free: true}
+//	  - name: jne handling
+//	    when: {couriers: [jne]}
+//	    then: {add: 2000}
+//	  - name: hide slow services
+//	    when: {etd_above: 5}
+//	    then: {hide: true}
+//	  - name: papua surcharge
+//	    when: {provinces: ["24"]}
+//	    then: {add_percent: 10}
+package rajaongkirrules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+)
+
+// Condition selects the rates a rule applies to. Empty fields match everything
+type Condition struct {
+	Couriers []string `json:"couriers,omitempty" yaml:"couriers,omitempty"`
+	Services []string `json:"services,omitempty" yaml:"services,omitempty"`
+	// Provinces are destination province IDs
+	Provinces     []string `json:"provinces,omitempty" yaml:"provinces,omitempty"`
+	MinOrderTotal int      `json:"min_order_total,omitempty" yaml:"min_order_total,omitempty"`
+	MaxOrderTotal int      `json:"max_order_total,omitempty" yaml:"max_order_total,omitempty"`
+	// ETDAbove matches services whose longest delivery estimate is over this many days
+	ETDAbove int `json:"etd_above,omitempty" yaml:"etd_above,omitempty"`
+}
+
+// Action is what a rule does to a matching rate
+type Action struct {
+	Hide bool `json:"hide,omitempty" yaml:"hide,omitempty"`
+	Free bool `json:"free,omitempty" yaml:"free,omitempty"`
+	// Add is a flat amount in rupiah added to the price, negative for a discount
+	Add int `json:"add,omitempty" yaml:"add,omitempty"`
+	// AddPercent is a percentage of the current price added to it
+	AddPercent float64 `json:"add_percent,omitempty" yaml:"add_percent,omitempty"`
+}
+
+// Rule is a named condition and action
+type Rule struct {
+	Name string    `json:"name" yaml:"name"`
+	When Condition `json:"when" yaml:"when"`
+	Then Action    `json:"then" yaml:"then"`
+}
+
+// Validate checks that r has a name and a single kind of action
+func (r Rule) Validate() error {
+	if r.Name == "" {
+		return errors.New("rule has no name")
+	}
+	kinds := 0
+	for _, set := range []bool{r.Then.Hide, r.Then.Free, r.Then.Add != 0 || r.Then.AddPercent != 0} {
+		if set {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return fmt.Errorf("rule %q: must either hide, make free or add to the price", r.Name)
+	}
+	return nil
+}
+
+// Order holds what rules may look at besides the quote itself
+type Order struct {
+	// Total is the value of the goods in rupiah
+	Total               int
+	DestinationProvince rajaongkir.ProvinceID
+}
+
+// Applied records a rule that changed a rate
+type Applied struct {
+	Rule   string
+	Effect string
+}
+
+// Rate is a customer-facing price for a courier service
+type Rate struct {
+	Courier     string
+	Service     string
+	Description string
+	ETD         string
+	// Cost is the price quoted by RajaOngkir, Price is what the customer pays
+	Cost    int
+	Price   int
+	Applied []Applied
+	// Estimated is set if Cost came from a rajaongkir.Fallback
+	Estimated bool
+}
+
+// Result holds the rates to show and the rates hidden by rules
+type Result struct {
+	Rates  []Rate
+	Hidden []Rate
+}
+
+// Engine applies rules to quotes
+type Engine struct {
+	rules []Rule
+}
+
+// New returns an Engine applying rules in order
+func New(rules ...Rule) (*Engine, error) {
+	for _, r := range rules {
+		if err := r.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &Engine{rules: append([]Rule(nil), rules...)}, nil
+}
+
+type file struct {
+	Rules []Rule `json:"rules" yaml:"rules"`
+}
+
+// LoadJSON reads rules from a JSON document with a top level "rules" list
+func LoadJSON(r io.Reader) (*Engine, error) {
+	f := file{}
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("rajaongkirrules: %w", err)
+	}
+	return New(f.Rules...)
+}
+
+// LoadYAML reads rules from a YAML document with a top level "rules" list
+func LoadYAML(r io.Reader) (*Engine, error) {
+	f := file{}
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("rajaongkirrules: %w", err)
+	}
+	return New(f.Rules...)
+}
+
+// LoadFile reads rules from path, as YAML if it ends in .yaml or .yml and as JSON otherwise
+func LoadFile(path string) (*Engine, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return LoadYAML(f)
+	}
+	return LoadJSON(f)
+}
+
+// Apply turns the costs quoted by courier into rates for order
+func (e *Engine) Apply(order Order, courier string, costs []rajaongkir.Cost) Result {
+	res := Result{Rates: []Rate{}, Hidden: []Rate{}}
+	for _, c := range costs {
+		for _, detail := range c.Cost {
+			rate := Rate{
+				Courier:     courier,
+				Service:     c.Service,
+				Description: c.Description,
+				ETD:         detail.ETD,
+				Cost:        detail.Value,
+				Price:       detail.Value,
+				Applied:     []Applied{},
+				Estimated:   c.Estimated,
+			}
+			if e.apply(order, &rate) {
+				res.Rates = append(res.Rates, rate)
+			} else {
+				res.Hidden = append(res.Hidden, rate)
+			}
+		}
+	}
+	return res
+}
+
+// apply runs the rules on rate and reports whether it is still shown
+func (e *Engine) apply(order Order, rate *Rate) bool {
+	free := false
+	for _, r := range e.rules {
+		if !r.When.matches(order, rate) {
+			continue
+		}
+		switch {
+		case r.Then.Hide:
+			rate.Applied = append(rate.Applied, Applied{Rule: r.Name, Effect: "hidden"})
+			return false
+		case free:
+			continue
+		case r.Then.Free:
+			rate.Applied = append(rate.Applied, Applied{Rule: r.Name, Effect: "free"})
+			rate.Price = 0
+			free = true
+			continue
+		}
+		before := rate.Price
+		rate.Price += int(math.Round(float64(rate.Price) * r.Then.AddPercent / 100))
+		rate.Price = max(rate.Price+r.Then.Add, 0)
+		rate.Applied = append(rate.Applied, Applied{Rule: r.Name, Effect: signed(rate.Price - before)})
+	}
+	return true
+}
+
+func signed(n int) string {
+	if n >= 0 {
+		return "+" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
+
+func (c Condition) matches(order Order, rate *Rate) bool {
+	if len(c.Couriers) > 0 && !contains(c.Couriers, rate.Courier) {
+		return false
+	}
+	if len(c.Services) > 0 && !contains(c.Services, rate.Service) {
+		return false
+	}
+	if len(c.Provinces) > 0 && !contains(c.Provinces, string(order.DestinationProvince)) {
+		return false
+	}
+	if c.MinOrderTotal > 0 && order.Total < c.MinOrderTotal {
+		return false
+	}
+	if c.MaxOrderTotal > 0 && order.Total > c.MaxOrderTotal {
+		return false
+	}
+	if c.ETDAbove > 0 {
+		_, days, ok := rajaongkir.ParseETD(rate.ETD)
+		if !ok || days <= c.ETDAbove {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
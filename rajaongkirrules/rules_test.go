@@ -0,0 +1,181 @@
+package rajaongkirrules
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+)
+
+const rulesYAML = `
+rules:
+  - name: free over 500k
+    when: {min_order_total: 500000, services: [REG]}
+    then: {free: true}
+  - name: jne handling
+    when: {couriers: [jne]}
+    then: {add: 2000}
+  - name: hide slow services
+    when: {etd_above: 4}
+    then: {hide: true}
+  - name: papua surcharge
+    when: {provinces: ["24"]}
+    then: {add_percent: 10}
+`
+
+const rulesJSON = `{"rules":[
+  {"name":"free over 500k","when":{"min_order_total":500000,"services":["REG"]},"then":{"free":true}},
+  {"name":"jne handling","when":{"couriers":["jne"]},"then":{"add":2000}},
+  {"name":"hide slow services","when":{"etd_above":4},"then":{"hide":true}},
+  {"name":"papua surcharge","when":{"provinces":["24"]},"then":{"add_percent":10}}
+]}`
+
+func cost(service string, value int, etd string) rajaongkir.Cost {
+	c := rajaongkir.Cost{Service: service}
+	c.Cost = append(c.Cost, struct {
+		Value int    `json:"value"`
+		ETD   string `json:"etd"`
+		Note  string `json:"note"`
+	}{Value: value, ETD: etd})
+	return c
+}
+
+var costs = []rajaongkir.Cost{
+	cost("OKE", 38000, "4-5"),
+	cost("REG", 44000, "2-3"),
+	cost("YES", 98000, "1-1"),
+}
+
+func prices(res Result) map[string]int {
+	p := map[string]int{}
+	for _, r := range res.Rates {
+		p[r.Service] = r.Price
+	}
+	return p
+}
+
+func TestApply(t *testing.T) {
+	e, err := LoadYAML(strings.NewReader(rulesYAML))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	tables := []struct {
+		order    Order
+		courier  string
+		expected map[string]int
+	}{
+		{Order{Total: 100000, DestinationProvince: "5"}, "jne", map[string]int{"REG": 46000, "YES": 100000}},
+		{Order{Total: 600000, DestinationProvince: "5"}, "jne", map[string]int{"REG": 0, "YES": 100000}},
+		{Order{Total: 100000, DestinationProvince: "24"}, "jne", map[string]int{"REG": 50600, "YES": 110000}},
+		{Order{Total: 100000, DestinationProvince: "5"}, "tiki", map[string]int{"REG": 44000, "YES": 98000}},
+	}
+
+	for _, table := range tables {
+		res := e.Apply(table.order, table.courier, costs)
+		if p := prices(res); !reflect.DeepEqual(p, table.expected) {
+			t.Errorf("Wrong prices for %+v on %s. Got %v, expected %v", table.order, table.courier, p, table.expected)
+		}
+		if len(res.Hidden) != 1 || res.Hidden[0].Service != "OKE" {
+			t.Errorf("Expected OKE to be hidden. Got %+v", res.Hidden)
+		}
+	}
+}
+
+func TestApplyAudit(t *testing.T) {
+	e, err := LoadJSON(strings.NewReader(rulesJSON))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	res := e.Apply(Order{Total: 100000, DestinationProvince: "24"}, "jne", costs)
+	expected := []Applied{
+		{Rule: "jne handling", Effect: "+2000"},
+		{Rule: "papua surcharge", Effect: "+4600"},
+	}
+	if !reflect.DeepEqual(res.Rates[0].Applied, expected) {
+		t.Errorf("Wrong audit trail. Got %+v, expected %+v", res.Rates[0].Applied, expected)
+	}
+	if res.Rates[0].Cost != 44000 {
+		t.Errorf("Wrong original cost. Got %d, expected 44000", res.Rates[0].Cost)
+	}
+	hidden := res.Hidden[0].Applied
+	if hidden[len(hidden)-1] != (Applied{Rule: "hide slow services", Effect: "hidden"}) {
+		t.Errorf("Wrong audit trail for hidden rate. Got %+v", hidden)
+	}
+}
+
+func TestApplyFreeThenHide(t *testing.T) {
+	e, err := New(
+		Rule{Name: "free over 500k", When: Condition{MinOrderTotal: 500000}, Then: Action{Free: true}},
+		Rule{Name: "jne handling", When: Condition{Couriers: []string{"jne"}}, Then: Action{Add: 2000}},
+		Rule{Name: "hide slow services", When: Condition{ETDAbove: 4}, Then: Action{Hide: true}},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	res := e.Apply(Order{Total: 600000}, "jne", costs)
+	if p := prices(res); !reflect.DeepEqual(p, map[string]int{"REG": 0, "YES": 0}) {
+		t.Errorf("Wrong prices for a free order. Got %v, expected REG and YES free", p)
+	}
+	expected := []Applied{{Rule: "free over 500k", Effect: "free"}, {Rule: "hide slow services", Effect: "hidden"}}
+	if len(res.Hidden) != 1 || !reflect.DeepEqual(res.Hidden[0].Applied, expected) {
+		t.Errorf("Expected the slow service hidden after being made free. Got %+v", res.Hidden)
+	}
+}
+
+func TestApplyEstimated(t *testing.T) {
+	e, _ := New()
+	estimated := cost("REG", 44000, "2-3")
+	estimated.Estimated = true
+
+	res := e.Apply(Order{Total: 100000}, "jne", []rajaongkir.Cost{cost("OKE", 38000, "4-5"), estimated})
+	if res.Rates[0].Estimated || !res.Rates[1].Estimated {
+		t.Errorf("Wrong estimated flags. Got %+v", res.Rates)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tables := []struct {
+		name string
+		load func() (*Engine, error)
+	}{
+		{"unknown field", func() (*Engine, error) {
+			return LoadYAML(strings.NewReader("rules:\n  - name: x\n    then: {discount: 5}\n"))
+		}},
+		{"no action", func() (*Engine, error) {
+			return LoadJSON(strings.NewReader(`{"rules":[{"name":"x","when":{"couriers":["jne"]}}]}`))
+		}},
+		{"two actions", func() (*Engine, error) {
+			return LoadJSON(strings.NewReader(`{"rules":[{"name":"x","then":{"hide":true,"add":5}}]}`))
+		}},
+		{"no name", func() (*Engine, error) {
+			return New(Rule{Then: Action{Free: true}})
+		}},
+	}
+
+	for _, table := range tables {
+		if _, err := table.load(); err == nil {
+			t.Errorf("Expected an error for %s", table.name)
+		}
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{"rules.yaml": rulesYAML, "rules.json": rulesJSON} {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, []byte(content), 0o600)
+		e, err := LoadFile(path)
+		if err != nil {
+			t.Fatalf("Unexpected error loading %s: %s", name, err)
+		}
+		if len(e.rules) != 4 {
+			t.Errorf("Wrong number of rules in %s. Got %d, expected 4", name, len(e.rules))
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package rajaongkirtest
+
+import rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+
+// Subdistrict stores the details of a subdistrict, only served on the Pro tier
+type Subdistrict = rajaongkir.Subdistrict
+
+// Rate prices a courier service at a fixed amount per kilogram of the weight the courier bills,
+// see rajaongkir.Weight.Billable
+type Rate struct {
+	Courier     string
+	Service     string
+	Description string
+	PerKilogram int
+	ETD         string
+	// MaxWeight is the heaviest parcel in grams the service carries, 0 for no limit
+	MaxWeight int
+}
+
+// Fixtures is the data served by a Server
+type Fixtures struct {
+	Provinces    []rajaongkir.Province
+	Cities       []rajaongkir.City
+	Subdistricts []Subdistrict
+	Rates        []Rate
+	// Waybills are keyed by courier and waybill number, e.g. "jne:SOCAG00183235715"
+	Waybills map[string]rajaongkir.Waybill
+}
+
+// DefaultFixtures returns the data a Server starts with.
+// Provinces mirror the live API while cities, subdistricts, rates
+// and waybills are a small sample around DI Yogyakarta and Bali
+func DefaultFixtures() Fixtures {
+	return Fixtures{
+		Provinces: []rajaongkir.Province{
+			{ProvinceID: "1", Province: "Bali"},
+			{ProvinceID: "2", Province: "Bangka Belitung"},
+			{ProvinceID: "3", Province: "Banten"},
+			{ProvinceID: "4", Province: "Bengkulu"},
+			{ProvinceID: "5", Province: "DI Yogyakarta"},
+			{ProvinceID: "6", Province: "DKI Jakarta"},
+			{ProvinceID: "7", Province: "Gorontalo"},
+			{ProvinceID: "8", Province: "Jambi"},
+			{ProvinceID: "9", Province: "Jawa Barat"},
+			{ProvinceID: "10", Province: "Jawa Tengah"},
+			{ProvinceID: "11", Province: "Jawa Timur"},
+			{ProvinceID: "12", Province: "Kalimantan Barat"},
+			{ProvinceID: "13", Province: "Kalimantan Selatan"},
+			{ProvinceID: "14", Province: "Kalimantan Tengah"},
+			{ProvinceID: "15", Province: "Kalimantan Timur"},
+			{ProvinceID: "16", Province: "Kalimantan Utara"},
+			{ProvinceID: "17", Province: "Kepulauan Riau"},
+			{ProvinceID: "18", Province: "Lampung"},
+			{ProvinceID: "19", Province: "Maluku"},
+			{ProvinceID: "20", Province: "Maluku Utara"},
+			{ProvinceID: "21", Province: "Nanggroe Aceh Darussalam (NAD)"},
+			{ProvinceID: "22", Province: "Nusa Tenggara Barat (NTB)"},
+			{ProvinceID: "23", Province: "Nusa Tenggara Timur (NTT)"},
+			{ProvinceID: "24", Province: "Papua"},
+			{ProvinceID: "25", Province: "Papua Barat"},
+			{ProvinceID: "26", Province: "Riau"},
+			{ProvinceID: "27", Province: "Sulawesi Barat"},
+			{ProvinceID: "28", Province: "Sulawesi Selatan"},
+			{ProvinceID: "29", Province: "Sulawesi Tengah"},
+			{ProvinceID: "30", Province: "Sulawesi Tenggara"},
+			{ProvinceID: "31", Province: "Sulawesi Utara"},
+			{ProvinceID: "32", Province: "Sumatera Barat"},
+			{ProvinceID: "33", Province: "Sumatera Selatan"},
+			{ProvinceID: "34", Province: "Sumatera Utara"},
+		},
+		Cities: []rajaongkir.City{
+			{CityID: "39", ProvinceID: "5", Province: "DI Yogyakarta", Type: rajaongkir.CityTypeKabupaten, CityName: "Bantul", PostalCode: "55715"},
+			{CityID: "114", ProvinceID: "1", Province: "Bali", Type: rajaongkir.CityTypeKota, CityName: "Denpasar", PostalCode: "80227"},
+			{CityID: "135", ProvinceID: "5", Province: "DI Yogyakarta", Type: rajaongkir.CityTypeKabupaten, CityName: "Gunung Kidul", PostalCode: "55812"},
+			{CityID: "210", ProvinceID: "5", Province: "DI Yogyakarta", Type: rajaongkir.CityTypeKabupaten, CityName: "Kulon Progo", PostalCode: "55611"},
+			{CityID: "419", ProvinceID: "5", Province: "DI Yogyakarta", Type: rajaongkir.CityTypeKabupaten, CityName: "Sleman", PostalCode: "55513"},
+			{CityID: "501", ProvinceID: "5", Province: "DI Yogyakarta", Type: rajaongkir.CityTypeKota, CityName: "Yogyakarta", PostalCode: "55111"},
+		},
+		Subdistricts: []Subdistrict{
+			{SubdistrictID: "537", ProvinceID: "5", Province: "DI Yogyakarta", CityID: "39", City: "Bantul", Type: rajaongkir.CityTypeKabupaten, SubdistrictName: "Bambang Lipuro"},
+			{SubdistrictID: "538", ProvinceID: "5", Province: "DI Yogyakarta", CityID: "39", City: "Bantul", Type: rajaongkir.CityTypeKabupaten, SubdistrictName: "Banguntapan"},
+			{SubdistrictID: "539", ProvinceID: "5", Province: "DI Yogyakarta", CityID: "39", City: "Bantul", Type: rajaongkir.CityTypeKabupaten, SubdistrictName: "Bantul"},
+			{SubdistrictID: "6981", ProvinceID: "5", Province: "DI Yogyakarta", CityID: "501", City: "Yogyakarta", Type: rajaongkir.CityTypeKota, SubdistrictName: "Gondokusuman"},
+		},
+		Rates: []Rate{
+			{Courier: "jne", Service: "OKE", Description: "Ongkos Kirim Ekonomis", PerKilogram: 19000, ETD: "4-5"},
+			{Courier: "jne", Service: "REG", Description: "Layanan Reguler", PerKilogram: 22000, ETD: "2-3"},
+			{Courier: "jne", Service: "YES", Description: "Yakin Esok Sampai", PerKilogram: 49000, ETD: "1-1"},
+			{Courier: "pos", Service: "Paket Kilat Khusus", Description: "Paket Kilat Khusus", PerKilogram: 20000, ETD: "2-4 HARI"},
+			{Courier: "tiki", Service: "REG", Description: "Regular Service", PerKilogram: 21000, ETD: "3"},
+			{Courier: "tiki", Service: "ONS", Description: "Over Night Service", PerKilogram: 35000, ETD: "1"},
+		},
+		Waybills: map[string]rajaongkir.Waybill{
+			"jne:SOCAG00183235715": {
+				Delivered: true,
+				Summary: rajaongkir.WaybillSummary{
+					CourierCode:   "jne",
+					CourierName:   "Jalur Nugraha Ekakurir (JNE)",
+					WaybillNumber: "SOCAG00183235715",
+					ServiceCode:   "REG",
+					WaybillDate:   "2020-10-01",
+					ShipperName:   "TOKO SEBELAH",
+					ReceiverName:  "BUDI",
+					Origin:        "YOGYAKARTA",
+					Destination:   "DENPASAR",
+					Status:        "DELIVERED",
+				},
+				DeliveryStatus: rajaongkir.DeliveryStatus{
+					Status:      "DELIVERED",
+					PODReceiver: "BUDI",
+					PODDate:     "2020-10-03",
+					PODTime:     "14:12",
+				},
+				Manifest: []rajaongkir.Manifest{
+					{ManifestCode: "1", ManifestDescription: "Manifested", ManifestDate: "2020-10-01", ManifestTime: "18:01", CityName: "YOGYAKARTA"},
+					{ManifestCode: "3", ManifestDescription: "Received On Destination", ManifestDate: "2020-10-03", ManifestTime: "08:40", CityName: "DENPASAR"},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,460 @@
+// Package rajaongkirtest provides a fake RajaOngkir API for tests.
+//
+// The Server speaks the same JSON as the live API over TLS,
+// serves provinces, cities, subdistricts, costs and waybills from Fixtures,
+// checks the key header, enforces the rules of its Tier
+// and can be told to fail or slow down.
+//
+//	srv := rajaongkirtest.NewServer(rajaongkirtest.WithTier(rajaongkir.TierBasic))
+//	defer srv.Close()
+//	r := srv.NewClient()
+//	costs, err := r.GetCost("501", "114", 1700, "jne")
+package rajaongkirtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+)
+
+// DefaultKey is the API key accepted by a Server unless configured otherwise
+const DefaultKey = "TESTKEY"
+
+// Server is a fake RajaOngkir API
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	keys     map[string]bool
+	tier     rajaongkir.Tier
+	fixtures Fixtures
+	latency  time.Duration
+	faults   map[string][]Fault
+	requests []Request
+}
+
+// Fault describes an injected failure.
+// If Body is set it is sent as is, otherwise a JSON status
+// with Code and Description is returned
+type Fault struct {
+	HTTPStatus  int
+	Code        int
+	Description string
+	Body        string
+}
+
+// Request is a request received by a Server
+type Request struct {
+	Method   string
+	Endpoint string
+	Key      string
+	Query    url.Values
+	Form     url.Values
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithKeys replaces the accepted API keys
+func WithKeys(keys ...string) Option {
+	return func(s *Server) {
+		s.keys = map[string]bool{}
+		for _, k := range keys {
+			s.keys[k] = true
+		}
+	}
+}
+
+// WithTier sets the account type the Server behaves as. Defaults to TierStarter
+func WithTier(t rajaongkir.Tier) Option {
+	return func(s *Server) {
+		s.tier = t
+	}
+}
+
+// WithFixtures replaces the data served
+func WithFixtures(f Fixtures) Option {
+	return func(s *Server) {
+		s.fixtures = f
+	}
+}
+
+// WithLatency delays every response by d
+func WithLatency(d time.Duration) Option {
+	return func(s *Server) {
+		s.latency = d
+	}
+}
+
+// NewServer starts a Server with DefaultFixtures, accepting DefaultKey
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		keys:     map[string]bool{DefaultKey: true},
+		tier:     rajaongkir.TierStarter,
+		fixtures: DefaultFixtures(),
+		faults:   map[string][]Fault{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// BaseURL returns the base URL to pass to rajaongkir.New
+func (s *Server) BaseURL() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return strings.TrimPrefix(s.URL, "https://") + "/" + string(s.tier)
+}
+
+// NewClient returns a client for the Server using DefaultKey
+func (s *Server) NewClient(opts ...rajaongkir.Option) *rajaongkir.RajaOngkir {
+	return rajaongkir.New(DefaultKey, s.BaseURL(), s.Client(), opts...)
+}
+
+// SetTier changes the account type the Server behaves as
+func (s *Server) SetTier(t rajaongkir.Tier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tier = t
+}
+
+// SetFixtures replaces the data served
+func (s *Server) SetFixtures(f Fixtures) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fixtures = f
+}
+
+// SetLatency delays every following response by d
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// FailNext makes the next request to endpoint, e.g. "/cost", fail with f.
+// Calls queue up so several requests can be failed in a row
+func (s *Server) FailNext(endpoint string, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[endpoint] = append(s.faults[endpoint], f)
+}
+
+// Requests returns the requests received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+type envelope struct {
+	Rajaongkir response `json:"rajaongkir"`
+}
+
+type response struct {
+	Query              map[string]string `json:"query,omitempty"`
+	Status             status            `json:"status"`
+	OriginDetails      interface{}       `json:"origin_details,omitempty"`
+	DestinationDetails interface{}       `json:"destination_details,omitempty"`
+	Results            interface{}       `json:"results,omitempty"`
+	Result             interface{}       `json:"result,omitempty"`
+}
+
+type status struct {
+	Code        int    `json:"code"`
+	Description string `json:"description"`
+}
+
+type carrierService struct {
+	Code  string `json:"code"`
+	Name  string `json:"name"`
+	Costs []cost `json:"costs"`
+}
+
+type cost struct {
+	Service     string       `json:"service"`
+	Description string       `json:"description"`
+	Cost        []costDetail `json:"cost"`
+}
+
+type costDetail struct {
+	Value int    `json:"value"`
+	ETD   string `json:"etd"`
+	Note  string `json:"note"`
+}
+
+var courierNames = map[string]string{
+	"jne":  "Jalur Nugraha Ekakurir (JNE)",
+	"pos":  "POS Indonesia (POS)",
+	"tiki": "Citra Van Titipan Kilat (TIKI)",
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	endpoint := "/" + r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method:   r.Method,
+		Endpoint: endpoint,
+		Key:      r.Header.Get("key"),
+		Query:    r.URL.Query(),
+		Form:     r.PostForm,
+	})
+	latency := s.latency
+	var fault *Fault
+	if faults := s.faults[endpoint]; len(faults) > 0 {
+		fault = &faults[0]
+		s.faults[endpoint] = faults[1:]
+	}
+	validKey := s.keys[r.Header.Get("key")]
+	tier := s.tier
+	fixtures := s.fixtures
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if fault != nil {
+		writeFault(w, fault)
+		return
+	}
+	if !validKey {
+		writeError(w, http.StatusBadRequest, "Invalid key. API key tidak ditemukan di database RajaOngkir.")
+		return
+	}
+
+	switch {
+	case endpoint == "/province" && r.Method == http.MethodGet:
+		serveProvinces(w, r, fixtures)
+	case endpoint == "/city" && r.Method == http.MethodGet:
+		serveCities(w, r, fixtures)
+	case endpoint == "/subdistrict" && r.Method == http.MethodGet:
+		if !tier.HasSubdistricts() {
+			writeError(w, http.StatusBadRequest, "Bad request. Endpoint tidak tersedia untuk tipe akun Anda.")
+			return
+		}
+		serveSubdistricts(w, r, fixtures)
+	case endpoint == "/cost" && r.Method == http.MethodPost:
+		serveCost(w, r, fixtures, tier)
+	case endpoint == "/waybill" && r.Method == http.MethodPost:
+		if !tier.HasWaybill() {
+			writeError(w, http.StatusBadRequest, "Bad request. Endpoint tidak tersedia untuk tipe akun Anda.")
+			return
+		}
+		serveWaybill(w, r, fixtures, tier)
+	default:
+		writeError(w, http.StatusNotFound, "Not found. Endpoint tidak ditemukan.")
+	}
+}
+
+func serveProvinces(w http.ResponseWriter, r *http.Request, f Fixtures) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeResults(w, nil, f.Provinces)
+		return
+	}
+	result := rajaongkir.Province{}
+	for _, p := range f.Provinces {
+		if string(p.ProvinceID) == id {
+			result = p
+		}
+	}
+	writeResults(w, map[string]string{"id": id}, result)
+}
+
+func serveCities(w http.ResponseWriter, r *http.Request, f Fixtures) {
+	id := r.URL.Query().Get("id")
+	province := r.URL.Query().Get("province")
+	query := map[string]string{}
+	if province != "" {
+		query["province"] = province
+	}
+	if id != "" {
+		query["id"] = id
+		result := rajaongkir.City{}
+		for _, c := range f.Cities {
+			if string(c.CityID) == id && (province == "" || string(c.ProvinceID) == province) {
+				result = c
+			}
+		}
+		writeResults(w, query, result)
+		return
+	}
+	results := []rajaongkir.City{}
+	for _, c := range f.Cities {
+		if province == "" || string(c.ProvinceID) == province {
+			results = append(results, c)
+		}
+	}
+	writeResults(w, query, results)
+}
+
+func serveSubdistricts(w http.ResponseWriter, r *http.Request, f Fixtures) {
+	city := r.URL.Query().Get("city")
+	id := r.URL.Query().Get("id")
+	if city == "" {
+		writeError(w, http.StatusBadRequest, "Bad request. Parameter city harus diisi.")
+		return
+	}
+	query := map[string]string{"city": city}
+	if id != "" {
+		query["id"] = id
+		result := Subdistrict{}
+		for _, sd := range f.Subdistricts {
+			if string(sd.CityID) == city && sd.SubdistrictID == id {
+				result = sd
+			}
+		}
+		writeResults(w, query, result)
+		return
+	}
+	results := []Subdistrict{}
+	for _, sd := range f.Subdistricts {
+		if string(sd.CityID) == city {
+			results = append(results, sd)
+		}
+	}
+	writeResults(w, query, results)
+}
+
+func findCity(f Fixtures, id string) (rajaongkir.City, bool) {
+	for _, c := range f.Cities {
+		if string(c.CityID) == id {
+			return c, true
+		}
+	}
+	return rajaongkir.City{}, false
+}
+
+func serveCost(w http.ResponseWriter, r *http.Request, f Fixtures, tier rajaongkir.Tier) {
+	origin := r.PostForm.Get("origin")
+	destination := r.PostForm.Get("destination")
+	courier := r.PostForm.Get("courier")
+	weight, err := strconv.Atoi(r.PostForm.Get("weight"))
+	if err != nil || weight <= 0 {
+		writeError(w, http.StatusBadRequest, "Bad request. Weight harus diisi dengan angka.")
+		return
+	}
+	if tier != rajaongkir.TierPro && weight > 30000 {
+		writeError(w, http.StatusBadRequest, "Bad request. Weight tidak boleh lebih dari 30000 gram.")
+		return
+	}
+	originCity, ok := findCity(f, origin)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Bad request. Origin tidak valid.")
+		return
+	}
+	destinationCity, ok := findCity(f, destination)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Bad request. Destination tidak valid.")
+		return
+	}
+	couriers := strings.Split(courier, ":")
+	if len(couriers) > 1 && tier != rajaongkir.TierPro {
+		writeError(w, http.StatusBadRequest, "Bad request. Courier tidak valid.")
+		return
+	}
+	results := []carrierService{}
+	for _, c := range couriers {
+		if !tier.SupportsCourier(c) {
+			writeError(w, http.StatusBadRequest, "Bad request. Courier tidak valid.")
+			return
+		}
+		service := carrierService{Code: c, Name: courierNames[c], Costs: []cost{}}
+		if service.Name == "" {
+			service.Name = strings.ToUpper(c)
+		}
+		billable := rajaongkir.Weight(weight).Billable(c)
+		for _, rate := range f.Rates {
+			if rate.Courier == c && (rate.MaxWeight == 0 || weight <= rate.MaxWeight) {
+				service.Costs = append(service.Costs, cost{
+					Service:     rate.Service,
+					Description: rate.Description,
+					Cost:        []costDetail{{Value: rate.PerKilogram * billable.Grams() / 1000, ETD: rate.ETD}},
+				})
+			}
+		}
+		results = append(results, service)
+	}
+	writeJSON(w, http.StatusOK, envelope{response{
+		Query: map[string]string{
+			"origin":      origin,
+			"destination": destination,
+			"weight":      strconv.Itoa(weight),
+			"courier":     courier,
+		},
+		Status:             status{200, "OK"},
+		OriginDetails:      originCity,
+		DestinationDetails: destinationCity,
+		Results:            results,
+	}})
+}
+
+func serveWaybill(w http.ResponseWriter, r *http.Request, f Fixtures, tier rajaongkir.Tier) {
+	number := r.PostForm.Get("waybill")
+	courier := r.PostForm.Get("courier")
+	if !tier.SupportsCourier(courier) {
+		writeError(w, http.StatusBadRequest, "Bad request. Courier tidak valid.")
+		return
+	}
+	waybill, ok := f.Waybills[courier+":"+number]
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid waybill. Nomor resi %s tidak ditemukan.", number))
+		return
+	}
+	writeJSON(w, http.StatusOK, envelope{response{
+		Query:  map[string]string{"waybill": number, "courier": courier},
+		Status: status{200, "OK"},
+		Result: waybill,
+	}})
+}
+
+func writeResults(w http.ResponseWriter, query map[string]string, results interface{}) {
+	writeJSON(w, http.StatusOK, envelope{response{
+		Query:   query,
+		Status:  status{200, "OK"},
+		Results: results,
+	}})
+}
+
+func writeError(w http.ResponseWriter, code int, description string) {
+	writeJSON(w, code, envelope{response{Status: status{code, description}}})
+}
+
+func writeFault(w http.ResponseWriter, f *Fault) {
+	code := f.HTTPStatus
+	if code == 0 {
+		code = http.StatusBadRequest
+	}
+	if f.Body != "" {
+		w.WriteHeader(code)
+		fmt.Fprint(w, f.Body)
+		return
+	}
+	statusCode := f.Code
+	if statusCode == 0 {
+		statusCode = code
+	}
+	writeJSON(w, code, envelope{response{Status: status{statusCode, f.Description}}})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,198 @@
+package rajaongkirtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+)
+
+func TestServerLookups(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	ro := srv.NewClient()
+
+	provinces, err := ro.GetProvinces()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(provinces) != 34 {
+		t.Errorf("Wrong number of provinces. Got %d, expected 34", len(provinces))
+	}
+
+	province, err := ro.GetProvince("5")
+	if err != nil || province.Province != "DI Yogyakarta" {
+		t.Errorf("Wrong province. Got %v (%v), expected DI Yogyakarta", province, err)
+	}
+
+	cities, err := ro.GetCitiesInProvince("5")
+	if err != nil || len(cities) != 5 {
+		t.Errorf("Wrong number of cities. Got %d (%v), expected 5", len(cities), err)
+	}
+
+	city, err := ro.GetCity("5", "39")
+	if err != nil || city.CityName != "Bantul" {
+		t.Errorf("Wrong city. Got %v (%v), expected Bantul", city, err)
+	}
+
+	reqs := srv.Requests()
+	if len(reqs) != 4 {
+		t.Fatalf("Wrong number of requests recorded. Got %d, expected 4", len(reqs))
+	}
+	if reqs[3].Endpoint != "/city" || reqs[3].Query.Get("id") != "39" || reqs[3].Key != DefaultKey {
+		t.Errorf("Wrong request recorded. Got %+v", reqs[3])
+	}
+}
+
+func TestServerCost(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	ro := srv.NewClient()
+
+	costs, err := ro.GetCost("501", "114", 1700, "jne")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(costs) != 3 {
+		t.Fatalf("Wrong number of costs. Got %d, expected 3", len(costs))
+	}
+	if costs[0].Service != "OKE" || costs[0].Cost[0].Value != 38000 {
+		t.Errorf("Wrong cost. Got %+v, expected OKE at 38000", costs[0])
+	}
+}
+
+func TestServerRateMaxWeight(t *testing.T) {
+	f := DefaultFixtures()
+	f.Rates = []Rate{
+		{Courier: "jne", Service: "REG", PerKilogram: 22000, ETD: "2-3"},
+		{Courier: "jne", Service: "YES", PerKilogram: 49000, ETD: "1-1", MaxWeight: 5000},
+	}
+	srv := NewServer(WithFixtures(f))
+	defer srv.Close()
+	ro := srv.NewClient()
+
+	costs, err := ro.GetCost("501", "114", 5000, "jne")
+	if err != nil || len(costs) != 2 {
+		t.Errorf("Wrong number of costs at 5kg. Got %d (%v), expected 2", len(costs), err)
+	}
+	costs, err = ro.GetCost("501", "114", 5001, "jne")
+	if err != nil || len(costs) != 1 || costs[0].Service != "REG" {
+		t.Errorf("Wrong costs above 5kg. Got %+v (%v), expected only REG", costs, err)
+	}
+}
+
+func TestServerRejects(t *testing.T) {
+	tables := []struct {
+		tier    rajaongkir.Tier
+		key     string
+		courier string
+		weight  int
+		origin  string
+	}{
+		{rajaongkir.TierStarter, "WRONGKEY", "jne", 1000, "501"},
+		{rajaongkir.TierStarter, DefaultKey, "jnt", 1000, "501"},
+		{rajaongkir.TierStarter, DefaultKey, "jne:pos", 1000, "501"},
+		{rajaongkir.TierStarter, DefaultKey, "jne", 31000, "501"},
+		{rajaongkir.TierBasic, DefaultKey, "jne", 1000, "9999"},
+	}
+
+	for _, table := range tables {
+		srv := NewServer(WithTier(table.tier))
+		ro := rajaongkir.New(table.key, srv.BaseURL(), srv.Client())
+		_, err := ro.GetCost(table.origin, "114", table.weight, table.courier)
+		srv.Close()
+		if err == nil {
+			t.Errorf("Expected %+v to be rejected", table)
+		}
+	}
+}
+
+func TestServerTierEndpoints(t *testing.T) {
+	srv := NewServer(WithTier(rajaongkir.TierStarter))
+	defer srv.Close()
+
+	post := func(endpoint string, form url.Values) *http.Response {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+"/starter"+endpoint, strings.NewReader(form.Encode()))
+		req.Header.Set("key", DefaultKey)
+		req.Header.Set("content-type", "application/x-www-form-urlencoded")
+		res, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		res.Body.Close()
+		return res
+	}
+	get := func(endpoint string) *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/starter"+endpoint, nil)
+		req.Header.Set("key", DefaultKey)
+		res, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		res.Body.Close()
+		return res
+	}
+	waybill := url.Values{"waybill": {"SOCAG00183235715"}, "courier": {"jne"}}
+
+	if res := post("/waybill", waybill); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected waybill to be rejected on starter. Got %d", res.StatusCode)
+	}
+	if res := get("/subdistrict?city=39"); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected subdistrict to be rejected on starter. Got %d", res.StatusCode)
+	}
+
+	srv.SetTier(rajaongkir.TierPro)
+	if res := post("/waybill", waybill); res.StatusCode != http.StatusOK {
+		t.Errorf("Expected waybill to be served on pro. Got %d", res.StatusCode)
+	}
+	if res := get("/subdistrict?city=39"); res.StatusCode != http.StatusOK {
+		t.Errorf("Expected subdistrict to be served on pro. Got %d", res.StatusCode)
+	}
+}
+
+func TestServerFaults(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	ro := srv.NewClient()
+
+	srv.FailNext("/cost", Fault{HTTPStatus: http.StatusBadGateway, Body: "<html>502 Bad Gateway</html>"})
+	srv.FailNext("/cost", Fault{Code: 400, Description: "Daily limit exceeded"})
+
+	_, err := ro.GetCost("501", "114", 1700, "jne")
+	if !errors.Is(err, rajaongkir.ErrInvalidResponse) {
+		t.Errorf("Expected an invalid response error. Got %v", err)
+	}
+	_, err = ro.GetCost("501", "114", 1700, "jne")
+	if err == nil || err.Error() != "Daily limit exceeded" {
+		t.Errorf("Expected the injected status. Got %v", err)
+	}
+	_, err = ro.GetCost("501", "114", 1700, "jne")
+	if err != nil {
+		t.Errorf("Expected faults to be used up. Got %v", err)
+	}
+}
+
+func TestServerLatency(t *testing.T) {
+	srv := NewServer(WithLatency(time.Second))
+	defer srv.Close()
+	ro := srv.NewClient()
+
+	// Only the slow call gets a short deadline, the client keeps its normal timeout
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	_, err := ro.GetProvincesContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the request to time out. Got %v", err)
+	}
+
+	srv.SetLatency(0)
+	_, err = ro.GetProvinces()
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
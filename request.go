@@ -1,22 +1,63 @@
 package rajaongkir
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"strings"
+	"time"
 )
 
+const (
+	defaultMaxResponseSize = 10 << 20
+	maxSnippetSize         = 128
+)
+
+// Errors wrapped by ResponseError
+var (
+	ErrResponseTooLarge  = errors.New("response body exceeds the maximum size")
+	ErrTruncatedResponse = errors.New("response body is truncated")
+	ErrInvalidResponse   = errors.New("response body is not valid JSON")
+)
+
+// ResponseError is returned when a response body
+// cannot be decoded, e.g. an HTML error page from a proxy
+type ResponseError struct {
+	StatusCode  int
+	ContentType string
+	// Snippet holds the beginning of the body
+	Snippet string
+	Err     error
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("rajaongkir: %s (status %d, content-type %q): %q", e.Err, e.StatusCode, e.ContentType, e.Snippet)
+}
+
+func (e *ResponseError) Unwrap() error {
+	return e.Err
+}
+
+// WithMaxResponseSize limits the size of response bodies the client will read.
+// Defaults to 10MB
+func WithMaxResponseSize(n int64) Option {
+	return func(r *RajaOngkir) {
+		r.maxResponseSize = n
+	}
+}
+
 func (r *RajaOngkir) createTargetURL(endpoint string) string {
 	targetURL := fmt.Sprintf("https://%s%s", r.baseURL, endpoint)
 	return targetURL
 }
 
-func (r *RajaOngkir) createRequest(method, endpoint string, payloadString string) (*http.Request, error) {
+func (r *RajaOngkir) createRequest(ctx context.Context, method, endpoint string, payloadString string) (*http.Request, error) {
 	url := r.createTargetURL(endpoint)
 	payload := strings.NewReader(payloadString)
-	req, err := http.NewRequest(method, url, payload)
+	req, err := http.NewRequestWithContext(ctx, method, url, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -25,27 +66,135 @@ func (r *RajaOngkir) createRequest(method, endpoint string, payloadString string
 	return req, err
 }
 
-func (r *RajaOngkir) sendRequest(method, endpoint, payload string, vs interface{}) error {
-	// Create the request
-	req, err := r.createRequest(method, endpoint, payload)
+// callResult describes a finished API call
+type callResult struct {
+	method     string
+	endpoint   string
+	key        string
+	attempt    int
+	statusCode int
+	status     *status
+	duration   time.Duration
+	err        error
+}
+
+// failed reports whether the call errored or RajaOngkir rejected it
+func (c *callResult) failed() bool {
+	return c.err != nil || (c.status != nil && checkStatus(c.status) != nil)
+}
+
+// path returns the endpoint without its query string
+func (c *callResult) path() string {
+	path, _, _ := strings.Cut(c.endpoint, "?")
+	return path
+}
+
+func (r *RajaOngkir) sendRequest(ctx context.Context, method, endpoint, payload string, vs interface{}) error {
+	if r.hedgeDelay > 0 && hedgeable(endpoint) {
+		return r.hedge(ctx, method, endpoint, payload, vs)
+	}
+	return r.call(ctx, method, endpoint, payload, vs)
+}
+
+// attempt makes a single call with key, guarded, throttled and observed.
+// n counts the attempts made with different keys
+func (r *RajaOngkir) attempt(ctx context.Context, key string, n int, method, endpoint, payload string, vs interface{}) error {
+	path, _, _ := strings.Cut(endpoint, "?")
+	done, err := r.guard(ctx, path)
 	if err != nil {
 		return err
 	}
+	if err := r.wait(ctx); err != nil {
+		done(nil)
+		return err
+	}
+	start := time.Now()
+	statusCode, err := r.doRequest(ctx, key, method, endpoint, payload, vs)
+	res := &callResult{
+		method:     method,
+		endpoint:   endpoint,
+		key:        key,
+		attempt:    n,
+		statusCode: statusCode,
+		duration:   time.Since(start),
+		err:        err,
+	}
+	if re, ok := vs.(responder); ok && err == nil {
+		res.status = re.responseStatus()
+	}
+	if errors.Is(context.Cause(ctx), errHedgeLost) {
+		// The other attempt already answered, this one says nothing about the API
+		done(nil)
+		return err
+	}
+	r.logRequest(res)
+	r.observeRequest(res)
+	r.traceRequest(ctx, res)
+	done(res)
+	return err
+}
+
+// doRequest performs a single round trip and returns the HTTP status code
+// along with any error encountered
+func (r *RajaOngkir) doRequest(ctx context.Context, key, method, endpoint, payload string, vs interface{}) (int, error) {
+	// Create the request
+	req, err := r.createRequest(ctx, method, endpoint, payload)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("key", key)
 	// Execute it
 	res, err := r.client.Do(req)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer res.Body.Close()
-	// Read from the body
-	body, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return err
-	}
 	// Parse the body
-	err = json.Unmarshal(body, &vs)
-	if err != nil {
-		return err
+	err = r.decodeResponse(res, vs)
+	return res.StatusCode, err
+}
+
+// decodeResponse streams the body of res into vs,
+// reading at most maxResponseSize bytes
+func (r *RajaOngkir) decodeResponse(res *http.Response, vs interface{}) error {
+	limit := r.maxResponseSize
+	if limit <= 0 {
+		limit = defaultMaxResponseSize
 	}
-	return err
+	body := &io.LimitedReader{R: res.Body, N: limit + 1}
+	head := &snippetWriter{}
+	err := json.NewDecoder(io.TeeReader(body, head)).Decode(vs)
+	if err == nil {
+		return nil
+	}
+	var syntaxErr *json.SyntaxError
+	switch {
+	case body.N <= 0:
+		err = ErrResponseTooLarge
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		err = ErrTruncatedResponse
+	case errors.As(err, &syntaxErr):
+		err = fmt.Errorf("%w: %s", ErrInvalidResponse, err)
+	}
+	return &ResponseError{
+		StatusCode:  res.StatusCode,
+		ContentType: res.Header.Get("Content-Type"),
+		Snippet:     string(head.buf),
+		Err:         err,
+	}
+}
+
+// snippetWriter keeps the first bytes written to it
+type snippetWriter struct {
+	buf []byte
+}
+
+func (w *snippetWriter) Write(p []byte) (int, error) {
+	if n := maxSnippetSize - len(w.buf); n > 0 {
+		if len(p) < n {
+			n = len(p)
+		}
+		w.buf = append(w.buf, p[:n]...)
+	}
+	return len(p), nil
 }
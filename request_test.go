@@ -1,7 +1,14 @@
 package rajaongkir
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -41,7 +48,7 @@ func TestCreateRequest(t *testing.T) {
 	}
 
 	for _, table := range tables {
-		req, err := ro.createRequest(table.method, table.endpoint, table.payload)
+		req, err := ro.createRequest(context.Background(), table.method, table.endpoint, table.payload)
 
 		isErr := false
 		if err != nil {
@@ -88,7 +95,7 @@ func TestSendRequest(t *testing.T) {
 		ts, ro, _ := setupTest(table.fakeResponse)
 		defer ts.Close()
 		responseObject := table.responseObject
-		err := ro.sendRequest(table.method, table.endpoint, table.payload, responseObject)
+		err := ro.sendRequest(context.Background(), table.method, table.endpoint, table.payload, responseObject)
 
 		isErr := false
 		if err != nil {
@@ -102,3 +109,95 @@ func TestSendRequest(t *testing.T) {
 
 	}
 }
+
+func TestDecodeResponse(t *testing.T) {
+	tables := []struct {
+		body        string
+		maxSize     int64
+		expectedErr error
+	}{
+		{provinceRes, 0, nil},
+		{provinceRes, 64, ErrResponseTooLarge},
+		{provinceRes[:100], 0, ErrTruncatedResponse},
+		{"", 0, ErrTruncatedResponse},
+		{"<html><body>502 Bad Gateway</body></html>", 0, ErrInvalidResponse},
+	}
+
+	for _, table := range tables {
+		ro := New("APIKEY12345", "test.com", nil, WithMaxResponseSize(table.maxSize))
+		res := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"text/html"}},
+			Body:       io.NopCloser(strings.NewReader(table.body)),
+		}
+		re := &provinceResponse{}
+		err := ro.decodeResponse(res, re)
+
+		if !errors.Is(err, table.expectedErr) {
+			t.Errorf("Error mismatch. Got %v, expected %v", err, table.expectedErr)
+		}
+		if err == nil {
+			if re.Rajaongkir.Results.Province != "Kalimantan Barat" {
+				t.Errorf("Wrong province decoded. Got %s", re.Rajaongkir.Results.Province)
+			}
+			continue
+		}
+		var resErr *ResponseError
+		if !errors.As(err, &resErr) {
+			t.Errorf("Expected a *ResponseError. Got %T", err)
+			continue
+		}
+		if resErr.ContentType != "text/html" {
+			t.Errorf("Wrong content type. Got %s, expected text/html", resErr.ContentType)
+		}
+		if !strings.HasPrefix(table.body, resErr.Snippet) || len(resErr.Snippet) > maxSnippetSize {
+			t.Errorf("Wrong snippet. Got %q", resErr.Snippet)
+		}
+	}
+}
+
+// largeCitiesBody builds a /city response with n entries
+func largeCitiesBody(n int) []byte {
+	re := &citiesResponse{}
+	re.Rajaongkir.Status = status{Code: 200, Description: "OK"}
+	for i := 0; i < n; i++ {
+		re.Rajaongkir.Results = append(re.Rajaongkir.Results, City{
+			CityID:     CityID(strconv.Itoa(i + 1)),
+			ProvinceID: "5",
+			Province:   "DI Yogyakarta",
+			Type:       CityTypeKabupaten,
+			CityName:   "Bantul",
+			PostalCode: "55715",
+		})
+	}
+	body, _ := json.Marshal(re)
+	return body
+}
+
+func BenchmarkDecodeReadAll(b *testing.B) {
+	body := largeCitiesBody(500)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		data, err := io.ReadAll(bytes.NewReader(body))
+		if err != nil {
+			b.Fatal(err)
+		}
+		re := &citiesResponse{}
+		if err := json.Unmarshal(data, re); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeStream(b *testing.B) {
+	body := largeCitiesBody(500)
+	ro := New("APIKEY12345", "test.com", nil)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		res := &http.Response{Body: io.NopCloser(bytes.NewReader(body))}
+		re := &citiesResponse{}
+		if err := ro.decodeResponse(res, re); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package rajaongkir
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// cityAliases maps common abbreviations and spellings to RajaOngkir city names
+var cityAliases = map[string]string{
+	"jogja":       "yogyakarta",
+	"jogjakarta":  "yogyakarta",
+	"djogja":      "yogyakarta",
+	"yogya":       "yogyakarta",
+	"jkt":         "jakarta",
+	"jakbar":      "jakarta barat",
+	"jakpus":      "jakarta pusat",
+	"jaksel":      "jakarta selatan",
+	"jaktim":      "jakarta timur",
+	"jakut":       "jakarta utara",
+	"bdg":         "bandung",
+	"sby":         "surabaya",
+	"smg":         "semarang",
+	"mks":         "makassar",
+	"dps":         "denpasar",
+	"plg":         "palembang",
+	"mdn":         "medan",
+	"bpn":         "balikpapan",
+	"gunungkidul": "gunung kidul",
+	"kulonprogo":  "kulon progo",
+}
+
+// wordAliases expands abbreviated words anywhere in a name
+var wordAliases = map[string]string{
+	"brt": "barat",
+	"tmr": "timur",
+	"utr": "utara",
+	"sel": "selatan",
+	"tgh": "tengah",
+	"pst": "pusat",
+}
+
+// typePrefixes are stripped from queries, most specific first,
+// and recorded as a hint of the city type
+var typePrefixes = []struct {
+	prefix string
+	kind   CityType
+}{
+	{"kota administrasi ", CityTypeKota},
+	{"kota adm ", CityTypeKota},
+	{"kabupaten ", CityTypeKabupaten},
+	{"kota ", CityTypeKota},
+	{"kab ", CityTypeKabupaten},
+}
+
+var diacritics = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a",
+	"è", "e", "é", "e", "ê", "e", "ë", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i",
+	"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ù", "u", "ú", "u", "û", "u", "ü", "u",
+	"ñ", "n", "ç", "c", "ý", "y", "ÿ", "y",
+)
+
+// normalizeQuery normalizes s and removes any Kota/Kabupaten prefix.
+// It returns the remaining name and the city type hinted by the prefix, if any
+func normalizeQuery(s string) (string, CityType) {
+	s = normalizeName(s)
+	for _, p := range typePrefixes {
+		if strings.HasPrefix(s, p.prefix) {
+			return normalizeName(strings.TrimPrefix(s, p.prefix)), p.kind
+		}
+	}
+	return s, CityTypeUnknown
+}
+
+// normalizeName lowercases s, strips diacritics and punctuation
+// and expands known abbreviations
+func normalizeName(s string) string {
+	s = diacritics.Replace(strings.ToLower(s))
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, s)
+	s = strings.Join(strings.Fields(s), " ")
+	if alias, ok := cityAliases[s]; ok {
+		return alias
+	}
+	words := strings.Fields(s)
+	for i, w := range words {
+		if alias, ok := cityAliases[w]; ok {
+			words[i] = alias
+		} else if alias, ok := wordAliases[w]; ok {
+			words[i] = alias
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+type searchEntry struct {
+	city City
+	name string
+}
+
+// CitySearch matches user typed names such as "jogja" or "kab. bantul"
+// against a list of cities
+type CitySearch struct {
+	entries []searchEntry
+}
+
+// NewCitySearch indexes cities, e.g. as returned by GetCities, for searching
+func NewCitySearch(cities []City) *CitySearch {
+	s := &CitySearch{entries: make([]searchEntry, len(cities))}
+	for i, c := range cities {
+		s.entries[i] = searchEntry{city: c, name: normalizeName(c.CityName)}
+	}
+	return s
+}
+
+// score rates how well name matches query, 0 meaning no match
+func score(query, name string) int {
+	switch {
+	case name == query:
+		return 100
+	case strings.HasPrefix(name, query):
+		return 80
+	case strings.Contains(" "+name, " "+query):
+		return 70
+	case strings.Contains(name, query):
+		return 60
+	}
+	// Allow roughly one typo per four characters
+	maxDistance := len(query) / 4
+	if maxDistance == 0 {
+		return 0
+	}
+	best := levenshtein(query, name)
+	for _, w := range strings.Fields(name) {
+		best = min(best, levenshtein(query, w))
+	}
+	if best > maxDistance {
+		return 0
+	}
+	return 50 - 10*best
+}
+
+// SearchCities returns at most limit cities whose name matches query, best match first.
+// A limit of 0 or less returns every match
+func (s *CitySearch) SearchCities(query string, limit int) []City {
+	return s.search("", query, limit)
+}
+
+// SearchCitiesInProvince is like SearchCities but only considers cities in provinceID
+func (s *CitySearch) SearchCitiesInProvince(provinceID, query string, limit int) []City {
+	return s.search(provinceID, query, limit)
+}
+
+func (s *CitySearch) search(provinceID, query string, limit int) []City {
+	q, kind := normalizeQuery(query)
+	if q == "" {
+		return []City{}
+	}
+	type match struct {
+		city  City
+		score int
+	}
+	matches := []match{}
+	for _, e := range s.entries {
+		if provinceID != "" && e.city.ProvinceID != ProvinceID(provinceID) {
+			continue
+		}
+		sc := score(q, e.name)
+		if sc == 0 {
+			continue
+		}
+		if kind != CityTypeUnknown && e.city.Type == kind {
+			sc += 5
+		}
+		matches = append(matches, match{e.city, sc})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		if matches[i].city.CityName != matches[j].city.CityName {
+			return matches[i].city.CityName < matches[j].city.CityName
+		}
+		return lessID(string(matches[i].city.CityID), string(matches[j].city.CityID))
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	cities := make([]City, len(matches))
+	for i, m := range matches {
+		cities[i] = m.city
+	}
+	return cities
+}
+
+// lessID orders numeric IDs by value, as the API returns them
+func lessID(a, b string) bool {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	if errA != nil || errB != nil {
+		return a < b
+	}
+	return na < nb
+}
@@ -0,0 +1,88 @@
+package rajaongkir
+
+import "testing"
+
+var searchCities = []City{
+	{CityID: "22", ProvinceID: "9", Province: "Jawa Barat", Type: CityTypeKabupaten, CityName: "Bandung", PostalCode: "40311"},
+	{CityID: "23", ProvinceID: "9", Province: "Jawa Barat", Type: CityTypeKota, CityName: "Bandung", PostalCode: "40111"},
+	{CityID: "39", ProvinceID: "5", Province: "DI Yogyakarta", Type: CityTypeKabupaten, CityName: "Bantul", PostalCode: "55715"},
+	{CityID: "135", ProvinceID: "5", Province: "DI Yogyakarta", Type: CityTypeKabupaten, CityName: "Gunung Kidul", PostalCode: "55812"},
+	{CityID: "153", ProvinceID: "6", Province: "DKI Jakarta", Type: CityTypeKota, CityName: "Jakarta Selatan", PostalCode: "12230"},
+	{CityID: "501", ProvinceID: "5", Province: "DI Yogyakarta", Type: CityTypeKota, CityName: "Yogyakarta", PostalCode: "55111"},
+}
+
+func TestNormalizeQuery(t *testing.T) {
+	tables := []struct {
+		query        string
+		expectedName string
+		expectedType CityType
+	}{
+		{"Kota Yogyakarta", "yogyakarta", CityTypeKota},
+		{"kab. bantul", "bantul", CityTypeKabupaten},
+		{"KABUPATEN  Gunung-Kidul", "gunung kidul", CityTypeKabupaten},
+		{"jogja", "yogyakarta", CityTypeUnknown},
+		{"Jaksel", "jakarta selatan", CityTypeUnknown},
+		{"jkt sel", "jakarta selatan", CityTypeUnknown},
+		{"Bantúl", "bantul", CityTypeUnknown},
+		{"Kota Adm. Jakarta Selatan", "jakarta selatan", CityTypeKota},
+	}
+
+	for _, table := range tables {
+		name, kind := normalizeQuery(table.query)
+		if name != table.expectedName || kind != table.expectedType {
+			t.Errorf("Wrong normalization of %q. Got %q/%s, expected %q/%s", table.query, name, kind, table.expectedName, table.expectedType)
+		}
+	}
+}
+
+func TestSearchCities(t *testing.T) {
+	s := NewCitySearch(searchCities)
+	tables := []struct {
+		query       string
+		limit       int
+		expectedIDs []string
+	}{
+		{"jogja", 1, []string{"501"}},
+		{"kab. bantul", 5, []string{"39"}},
+		{"Kota Yogyakarta", 5, []string{"501"}},
+		{"kab bandung", 5, []string{"22", "23"}},
+		{"kota bandung", 5, []string{"23", "22"}},
+		{"bandung", 1, []string{"22"}},
+		{"ban", 5, []string{"22", "23", "39"}},
+		{"kidul", 5, []string{"135"}},
+		{"yogyakrta", 5, []string{"501"}},
+		{"jaksel", 5, []string{"153"}},
+		{"", 5, []string{}},
+		{"xyz", 5, []string{}},
+	}
+
+	for _, table := range tables {
+		cities := s.SearchCities(table.query, table.limit)
+		ids := []string{}
+		for _, c := range cities {
+			ids = append(ids, string(c.CityID))
+		}
+		if len(ids) != len(table.expectedIDs) {
+			t.Errorf("Wrong results for %q. Got %v, expected %v", table.query, ids, table.expectedIDs)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != table.expectedIDs[i] {
+				t.Errorf("Wrong results for %q. Got %v, expected %v", table.query, ids, table.expectedIDs)
+				break
+			}
+		}
+	}
+}
+
+func TestSearchCitiesInProvince(t *testing.T) {
+	s := NewCitySearch(searchCities)
+	cities := s.SearchCitiesInProvince("5", "ban", 0)
+	if len(cities) != 1 || cities[0].CityID != "39" {
+		t.Errorf("Wrong results. Got %v, expected Bantul only", cities)
+	}
+	cities = s.SearchCities("ban", 0)
+	if len(cities) != 3 {
+		t.Errorf("Wrong number of results. Got %d, expected 3", len(cities))
+	}
+}
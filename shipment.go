@@ -0,0 +1,168 @@
+package rajaongkir
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// volumetricDivisor converts cubic centimetres to kilograms,
+// the divisor used by JNE, POS and TIKI for domestic shipments
+const volumetricDivisor = 6000
+
+// Parcel is a single box in a Shipment.
+// Weight is in grams, dimensions are in centimetres and may be left zero
+type Parcel struct {
+	Weight int
+	Length int
+	Width  int
+	Height int
+}
+
+// VolumetricWeight returns the weight in grams couriers charge for the space p takes up
+func (p Parcel) VolumetricWeight() int {
+	return p.Length * p.Width * p.Height * 1000 / volumetricDivisor
+}
+
+// ChargeableWeight returns the greater of the actual and volumetric weight
+func (p Parcel) ChargeableWeight() int {
+	return max(p.Weight, p.VolumetricWeight())
+}
+
+// Shipment is a set of parcels sent together from Origin to Destination
+type Shipment struct {
+	Origin      string
+	Destination string
+	Couriers    []string
+	Parcels     []Parcel
+}
+
+// ServiceQuote is the combined price of a courier service for every parcel in a shipment
+type ServiceQuote struct {
+	Courier     string
+	Service     string
+	Description string
+	ETD         string
+	Total       int
+	// Costs holds the price of each parcel, in the same order as Shipment.Parcels
+	Costs []int
+	// Estimated is set if any parcel was priced by a Fallback
+	Estimated bool
+}
+
+// UnavailableService reports a parcel a courier service could not quote.
+// Service is empty and Err is set if the courier could not quote the parcel at all
+type UnavailableService struct {
+	Courier string
+	Service string
+	Parcel  int
+	Err     error
+}
+
+// ShipmentQuote holds the quotes for the parcels of a shipment.
+// Services only lists services that can carry every parcel, cheapest first
+type ShipmentQuote struct {
+	Parcels     []Parcel
+	Services    []ServiceQuote
+	Unavailable []UnavailableService
+}
+
+// QuoteShipment quotes every parcel in s with every courier, at its chargeable weight,
+// and adds up the price of each service across parcels.
+// Requests are sent as a batch, see GetCostsBatch.
+// An error is returned if s is invalid or no parcel could be quoted
+func (r *RajaOngkir) QuoteShipment(ctx context.Context, s Shipment) (ShipmentQuote, error) {
+	if len(s.Parcels) == 0 {
+		return ShipmentQuote{}, errors.New("shipment has no parcels")
+	}
+	if len(s.Couriers) == 0 {
+		return ShipmentQuote{}, errors.New("shipment has no couriers")
+	}
+	for i, p := range s.Parcels {
+		if p.Weight <= 0 {
+			return ShipmentQuote{}, fmt.Errorf("parcel %d: weight must be positive", i)
+		}
+	}
+
+	reqs := make([]CostRequest, 0, len(s.Couriers)*len(s.Parcels))
+	for _, courier := range s.Couriers {
+		for _, p := range s.Parcels {
+			reqs = append(reqs, CostRequest{Origin: s.Origin, Destination: s.Destination, Weight: p.ChargeableWeight(), Courier: courier})
+		}
+	}
+	results := r.GetCostsBatch(ctx, reqs)
+
+	quote := ShipmentQuote{Parcels: s.Parcels, Services: []ServiceQuote{}, Unavailable: []UnavailableService{}}
+	for c, courier := range s.Couriers {
+		quotes, unavailable := combineParcels(courier, results[c*len(s.Parcels):(c+1)*len(s.Parcels)])
+		quote.Services = append(quote.Services, quotes...)
+		quote.Unavailable = append(quote.Unavailable, unavailable...)
+	}
+	if allFailed(results) {
+		return quote, results[0].Err
+	}
+	sort.SliceStable(quote.Services, func(i, j int) bool {
+		return quote.Services[i].Total < quote.Services[j].Total
+	})
+	return quote, nil
+}
+
+func allFailed(results []CostResult) bool {
+	for _, res := range results {
+		if res.Err == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// combineParcels adds up the cost of each service of courier across the results for every parcel.
+// Services missing from any parcel are reported as unavailable instead
+func combineParcels(courier string, results []CostResult) ([]ServiceQuote, []UnavailableService) {
+	quotes := map[string]*ServiceQuote{}
+	order := []string{}
+	unavailable := []UnavailableService{}
+	for i, res := range results {
+		if res.Err != nil {
+			unavailable = append(unavailable, UnavailableService{Courier: courier, Parcel: i, Err: res.Err})
+			continue
+		}
+		for _, c := range res.Costs {
+			if len(c.Cost) == 0 {
+				continue
+			}
+			q, ok := quotes[c.Service]
+			if !ok {
+				q = &ServiceQuote{Courier: courier, Service: c.Service, Description: c.Description, ETD: c.Cost[0].ETD, Costs: make([]int, len(results))}
+				for j := range q.Costs {
+					q.Costs[j] = -1
+				}
+				quotes[c.Service] = q
+				order = append(order, c.Service)
+			}
+			q.Costs[i] = c.Cost[0].Value
+			q.Estimated = q.Estimated || c.Estimated
+		}
+	}
+
+	complete := []ServiceQuote{}
+	for _, service := range order {
+		q := quotes[service]
+		ok := true
+		for i, cost := range q.Costs {
+			if cost < 0 {
+				ok = false
+				if results[i].Err == nil {
+					unavailable = append(unavailable, UnavailableService{Courier: courier, Service: service, Parcel: i})
+				}
+				continue
+			}
+			q.Total += cost
+		}
+		if ok {
+			complete = append(complete, *q)
+		}
+	}
+	return complete, unavailable
+}
@@ -0,0 +1,135 @@
+package rajaongkir
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// setupShipmentTest prices OKE at 19000 and YES at 49000 per started kilogram.
+// YES only carries up to 5kg and the "bad" courier is rejected
+func setupShipmentTest() (*httptest.Server, *RajaOngkir) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("courier") == "bad" {
+			fmt.Fprint(w, invalidCourierRes)
+			return
+		}
+		weight, _ := strconv.Atoi(r.PostForm.Get("weight"))
+		kilograms := (weight + 999) / 1000
+		costs := []string{fmt.Sprintf(`{"service":"OKE","description":"Ongkos Kirim Ekonomis","cost":[{"value":%d,"etd":"4-5","note":""}]}`, 19000*kilograms)}
+		if weight <= 5000 {
+			costs = append(costs, fmt.Sprintf(`{"service":"YES","description":"Yakin Esok Sampai","cost":[{"value":%d,"etd":"1-1","note":""}]}`, 49000*kilograms))
+		}
+		fmt.Fprintf(w, `{"rajaongkir":{"status":{"code":200,"description":"OK"},"results":[{"code":"jne","costs":[%s]}]}}`, strings.Join(costs, ","))
+	}
+	ts := httptest.NewTLSServer(http.HandlerFunc(handler))
+	hostname := strings.Replace(ts.URL, "https://", "", 1)
+	return ts, New("APIKEY12345", hostname, ts.Client())
+}
+
+func TestParcelWeight(t *testing.T) {
+	tables := []struct {
+		parcel     Parcel
+		volumetric int
+		chargeable int
+	}{
+		{Parcel{Weight: 1700}, 0, 1700},
+		{Parcel{Weight: 1700, Length: 30, Width: 20, Height: 10}, 1000, 1700},
+		{Parcel{Weight: 1000, Length: 40, Width: 40, Height: 40}, 10666, 10666},
+	}
+
+	for _, table := range tables {
+		if v := table.parcel.VolumetricWeight(); v != table.volumetric {
+			t.Errorf("Wrong volumetric weight for %+v. Got %d, expected %d", table.parcel, v, table.volumetric)
+		}
+		if c := table.parcel.ChargeableWeight(); c != table.chargeable {
+			t.Errorf("Wrong chargeable weight for %+v. Got %d, expected %d", table.parcel, c, table.chargeable)
+		}
+	}
+}
+
+func TestQuoteShipment(t *testing.T) {
+	ts, ro := setupShipmentTest()
+	defer ts.Close()
+
+	quote, err := ro.QuoteShipment(context.Background(), Shipment{
+		Origin:      "501",
+		Destination: "114",
+		Couriers:    []string{"jne", "bad"},
+		Parcels: []Parcel{
+			{Weight: 1700},
+			{Weight: 1000, Length: 40, Width: 40, Height: 40},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := []ServiceQuote{
+		{Courier: "jne", Service: "OKE", Description: "Ongkos Kirim Ekonomis", ETD: "4-5", Total: 38000 + 209000, Costs: []int{38000, 209000}},
+	}
+	if !reflect.DeepEqual(quote.Services, expected) {
+		t.Errorf("Wrong services. Got %+v, expected %+v", quote.Services, expected)
+	}
+
+	if len(quote.Unavailable) != 3 {
+		t.Fatalf("Wrong number of unavailable services. Got %+v, expected 3", quote.Unavailable)
+	}
+	if u := quote.Unavailable[0]; u.Courier != "jne" || u.Service != "YES" || u.Parcel != 1 || u.Err != nil {
+		t.Errorf("Wrong unavailable service. Got %+v, expected YES for parcel 1", u)
+	}
+	for _, u := range quote.Unavailable[1:] {
+		if u.Courier != "bad" || u.Service != "" || u.Err == nil {
+			t.Errorf("Wrong unavailable courier. Got %+v, expected bad with an error", u)
+		}
+	}
+}
+
+func TestQuoteShipmentErrors(t *testing.T) {
+	ts, ro := setupShipmentTest()
+	defer ts.Close()
+
+	tables := []struct {
+		shipment Shipment
+		expected string
+	}{
+		{Shipment{Origin: "501", Destination: "114", Couriers: []string{"jne"}}, "shipment has no parcels"},
+		{Shipment{Origin: "501", Destination: "114", Parcels: []Parcel{{Weight: 1000}}}, "shipment has no couriers"},
+		{Shipment{Origin: "501", Destination: "114", Couriers: []string{"jne"}, Parcels: []Parcel{{Weight: 1000}, {}}}, "parcel 1: weight must be positive"},
+		{Shipment{Origin: "501", Destination: "114", Couriers: []string{"bad"}, Parcels: []Parcel{{Weight: 1000}}}, "Bad request. Courier tidak valid."},
+	}
+
+	for _, table := range tables {
+		_, err := ro.QuoteShipment(context.Background(), table.shipment)
+		if err == nil || err.Error() != table.expected {
+			t.Errorf("Wrong error for %+v. Got %v, expected %s", table.shipment, err, table.expected)
+		}
+	}
+}
+
+func TestCombineParcelsEstimated(t *testing.T) {
+	oke := func(value int, estimated bool) []Cost {
+		c := Cost{Service: "OKE", Estimated: estimated}
+		c.Cost = append(c.Cost, struct {
+			Value int    `json:"value"`
+			ETD   string `json:"etd"`
+			Note  string `json:"note"`
+		}{Value: value, ETD: "4-5"})
+		return []Cost{c}
+	}
+
+	quotes, _ := combineParcels("jne", []CostResult{{Costs: oke(19000, false)}, {Costs: oke(38000, true)}})
+	if len(quotes) != 1 || !quotes[0].Estimated || quotes[0].Total != 57000 {
+		t.Errorf("Expected an estimated quote when any parcel is estimated. Got %+v", quotes)
+	}
+	quotes, _ = combineParcels("jne", []CostResult{{Costs: oke(19000, false)}, {Costs: oke(38000, false)}})
+	if len(quotes) != 1 || quotes[0].Estimated {
+		t.Errorf("Expected a real quote. Got %+v", quotes)
+	}
+}
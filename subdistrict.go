@@ -0,0 +1,62 @@
+package rajaongkir
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Subdistricts are only available on the Pro tier,
+// see https://rajaongkir.com/dokumentasi/pro
+const subdistrictEndpoint = "/subdistrict"
+
+// Subdistrict stores the details of a subdistrict (kecamatan).
+// RajaOngkir gives subdistricts no postal code of their own,
+// they share the one of their city
+type Subdistrict struct {
+	SubdistrictID   string     `json:"subdistrict_id"`
+	ProvinceID      ProvinceID `json:"province_id"`
+	Province        string     `json:"province"`
+	CityID          CityID     `json:"city_id"`
+	City            string     `json:"city"`
+	Type            CityType   `json:"type"`
+	SubdistrictName string     `json:"subdistrict_name"`
+}
+
+type subdistrictsResponse struct {
+	Rajaongkir struct {
+		Query   query         `json:"query"`
+		Status  status        `json:"status"`
+		Results []Subdistrict `json:"results"`
+	} `json:"rajaongkir"`
+}
+
+func (re *subdistrictsResponse) responseStatus() *status { return &re.Rajaongkir.Status }
+
+// GetSubdistricts fetches the list of subdistricts in cityID.
+// Requires a Pro API key
+func (r *RajaOngkir) GetSubdistricts(cityID string) ([]Subdistrict, error) {
+	return r.GetSubdistrictsContext(context.Background(), cityID)
+}
+
+// GetSubdistrictsContext is like GetSubdistricts but carries ctx
+func (r *RajaOngkir) GetSubdistrictsContext(ctx context.Context, cityID string) (subdistricts []Subdistrict, err error) {
+	if cityID == "" {
+		return nil, fmt.Errorf("cityID must be specified")
+	}
+	ctx, span := r.startSpan(ctx, "GetSubdistricts")
+	defer func() { finishSpan(span, err) }()
+	span.SetAttribute("rajaongkir.city_id", cityID)
+	re := &subdistrictsResponse{}
+	endpoint := fmt.Sprintf("%s?city=%s", subdistrictEndpoint, cityID)
+	err = r.sendRequest(ctx, http.MethodGet, endpoint, "", re)
+	if err != nil {
+		return []Subdistrict{}, err
+	}
+	err = checkStatus(&re.Rajaongkir.Status)
+	if err != nil {
+		return []Subdistrict{}, err
+	}
+	subdistricts = re.Rajaongkir.Results
+	return subdistricts, nil
+}
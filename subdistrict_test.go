@@ -0,0 +1,60 @@
+package rajaongkir
+
+import "testing"
+
+const subdistrictsRes string = `{
+    "rajaongkir": {
+        "query": {
+            "city": "39"
+        },
+        "status": {
+            "code": 200,
+            "description": "OK"
+        },
+        "results": [
+            {
+                "subdistrict_id": "537",
+                "province_id": "5",
+                "province": "DI Yogyakarta",
+                "city_id": "39",
+                "city": "Bantul",
+                "type": "Kabupaten",
+                "subdistrict_name": "Bambang Lipuro"
+            },
+            {
+                "subdistrict_id": "538",
+                "province_id": "5",
+                "province": "DI Yogyakarta",
+                "city_id": "39",
+                "city": "Bantul",
+                "type": "Kabupaten",
+                "subdistrict_name": "Banguntapan"
+            }
+        ]
+    }
+}`
+
+func TestGetSubdistricts(t *testing.T) {
+	ts, ro, rec := setupTest(subdistrictsRes)
+	defer ts.Close()
+	subdistricts, err := ro.GetSubdistricts("39")
+	expectedMethod := "GET"
+	expectedEndpoint := "/subdistrict?city=39"
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if rec.receivedMethod != expectedMethod {
+		t.Errorf("Wrong method. Received %s, expected %s", rec.receivedMethod, expectedMethod)
+	}
+	if rec.receivedEndpoint != expectedEndpoint {
+		t.Errorf("Wrong endpoint. Received %s, expected %s", rec.receivedEndpoint, expectedEndpoint)
+	}
+	if len(subdistricts) != 2 || subdistricts[1].SubdistrictName != "Banguntapan" ||
+		subdistricts[1].CityID != "39" || subdistricts[1].Type != CityTypeKabupaten {
+		t.Errorf("Wrong subdistricts decoded. Got %+v", subdistricts)
+	}
+	if _, err := ro.GetSubdistricts(""); err == nil {
+		t.Errorf("Expected an error for a missing city ID")
+	}
+}
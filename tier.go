@@ -0,0 +1,44 @@
+package rajaongkir
+
+// Tier is a RajaOngkir account type.
+// It decides which endpoints and couriers an API key may use
+type Tier string
+
+// List of account types according to https://rajaongkir.com/dokumentasi
+const (
+	TierStarter Tier = "starter"
+	TierBasic   Tier = "basic"
+	TierPro     Tier = "pro"
+)
+
+var tierCouriers = map[Tier][]string{
+	TierStarter: {"jne", "pos", "tiki"},
+	TierBasic:   {"jne", "pos", "tiki", "pcp", "esl", "rpx"},
+	TierPro: {"jne", "pos", "tiki", "rpx", "pandu", "wahana", "sicepat", "jnt", "pahala", "sap",
+		"jet", "indah", "dse", "slis", "first", "ncs", "star", "ninja", "lion", "idl", "rex", "ide", "sentral"},
+}
+
+// Couriers returns the couriers available on t
+func (t Tier) Couriers() []string {
+	return append([]string(nil), tierCouriers[t]...)
+}
+
+// SupportsCourier reports whether courier is available on t
+func (t Tier) SupportsCourier(courier string) bool {
+	for _, c := range tierCouriers[t] {
+		if c == courier {
+			return true
+		}
+	}
+	return false
+}
+
+// HasWaybill reports whether t can track shipments
+func (t Tier) HasWaybill() bool {
+	return t == TierBasic || t == TierPro
+}
+
+// HasSubdistricts reports whether t can look up and quote subdistricts
+func (t Tier) HasSubdistricts() bool {
+	return t == TierPro
+}
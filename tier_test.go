@@ -0,0 +1,31 @@
+package rajaongkir
+
+import "testing"
+
+func TestTier(t *testing.T) {
+	tables := []struct {
+		tier            Tier
+		courier         string
+		supportsCourier bool
+		hasWaybill      bool
+		hasSubdistricts bool
+	}{
+		{TierStarter, "jne", true, false, false},
+		{TierStarter, "jnt", false, false, false},
+		{TierBasic, "rpx", true, true, false},
+		{TierPro, "sicepat", true, true, true},
+		{Tier("gold"), "jne", false, false, false},
+	}
+
+	for _, table := range tables {
+		if table.tier.SupportsCourier(table.courier) != table.supportsCourier {
+			t.Errorf("Wrong courier support for %s on %s. Expected %v", table.courier, table.tier, table.supportsCourier)
+		}
+		if table.tier.HasWaybill() != table.hasWaybill {
+			t.Errorf("Wrong waybill support on %s. Expected %v", table.tier, table.hasWaybill)
+		}
+		if table.tier.HasSubdistricts() != table.hasSubdistricts {
+			t.Errorf("Wrong subdistrict support on %s. Expected %v", table.tier, table.hasSubdistricts)
+		}
+	}
+}
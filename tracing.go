@@ -0,0 +1,67 @@
+package rajaongkir
+
+import "context"
+
+// Tracer starts a span around every API call.
+// See the rajaongkirotel package for an OpenTelemetry implementation
+type Tracer interface {
+	// Start begins a span named name as a child of any span in ctx
+	// and returns a context carrying it
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a single traced operation
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// WithTracer traces every API call with t
+func WithTracer(t Tracer) Option {
+	return func(r *RajaOngkir) {
+		r.tracer = t
+	}
+}
+
+type spanKey struct{}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(string, interface{}) {}
+func (noopSpan) RecordError(error)                {}
+func (noopSpan) End()                             {}
+
+func (r *RajaOngkir) startSpan(ctx context.Context, name string) (context.Context, Span) {
+	if r.tracer == nil {
+		return ctx, noopSpan{}
+	}
+	ctx, span := r.tracer.Start(ctx, "rajaongkir."+name)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func spanFromContext(ctx context.Context) Span {
+	if span, ok := ctx.Value(spanKey{}).(Span); ok {
+		return span
+	}
+	return noopSpan{}
+}
+
+func finishSpan(span Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+func (r *RajaOngkir) traceRequest(ctx context.Context, res *callResult) {
+	span := spanFromContext(ctx)
+	span.SetAttribute("rajaongkir.endpoint", res.path())
+	span.SetAttribute("http.request.method", res.method)
+	if res.statusCode != 0 {
+		span.SetAttribute("http.response.status_code", res.statusCode)
+	}
+	if res.status != nil {
+		span.SetAttribute("rajaongkir.status", res.status.Code)
+	}
+}
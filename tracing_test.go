@@ -0,0 +1,91 @@
+package rajaongkir
+
+import (
+	"context"
+	"testing"
+)
+
+type fakeSpan struct {
+	name       string
+	parent     *fakeSpan
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *fakeSpan) SetAttribute(key string, value interface{}) { s.attributes[key] = value }
+func (s *fakeSpan) RecordError(err error)                      { s.err = err }
+func (s *fakeSpan) End()                                       { s.ended = true }
+
+type fakeSpanKey struct{}
+
+type fakeTracer struct {
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	parent, _ := ctx.Value(fakeSpanKey{}).(*fakeSpan)
+	span := &fakeSpan{name: name, parent: parent, attributes: map[string]interface{}{}}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, fakeSpanKey{}, span), span
+}
+
+func TestTraceGetCost(t *testing.T) {
+	tracer := &fakeTracer{}
+	ts, ro, _ := setupTest(costRes, WithTracer(tracer))
+	defer ts.Close()
+
+	ctx, parent := tracer.Start(context.Background(), "checkout")
+	_, err := ro.GetCostContext(ctx, "501", "114", 1700, "jne")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if len(tracer.spans) != 2 {
+		t.Fatalf("Wrong number of spans. Got %d, expected 2", len(tracer.spans))
+	}
+	span := tracer.spans[1]
+	if span.name != "rajaongkir.GetCost" {
+		t.Errorf("Wrong span name. Got %s, expected rajaongkir.GetCost", span.name)
+	}
+	if span.parent != parent {
+		t.Errorf("Span not propagated from the caller's context")
+	}
+	if !span.ended {
+		t.Errorf("Span not ended")
+	}
+	expected := map[string]interface{}{
+		"rajaongkir.endpoint":       "/cost",
+		"rajaongkir.origin":         "501",
+		"rajaongkir.destination":    "114",
+		"rajaongkir.weight":         1700,
+		"rajaongkir.courier":        "jne",
+		"rajaongkir.status":         200,
+		"http.request.method":       "POST",
+		"http.response.status_code": 200,
+	}
+	for key, value := range expected {
+		if span.attributes[key] != value {
+			t.Errorf("Wrong %s attribute. Got %v, expected %v", key, span.attributes[key], value)
+		}
+	}
+}
+
+func TestTraceError(t *testing.T) {
+	tracer := &fakeTracer{}
+	ts, ro, _ := setupTest(provincesRes, WithTracer(tracer))
+	defer ts.Close()
+
+	ro.GetProvinces()
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("Wrong number of spans. Got %d, expected 1", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.err == nil {
+		t.Errorf("Expected the RajaOngkir status error to be recorded")
+	}
+	if span.attributes["rajaongkir.status"] != 400 {
+		t.Errorf("Wrong status attribute. Got %v, expected 400", span.attributes["rajaongkir.status"])
+	}
+}
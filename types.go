@@ -0,0 +1,144 @@
+package rajaongkir
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// CityType tells a Kota (city) from a Kabupaten (regency)
+type CityType int
+
+// List of city types
+const (
+	CityTypeUnknown CityType = iota
+	CityTypeKota
+	CityTypeKabupaten
+)
+
+var cityTypeNames = map[CityType]string{
+	CityTypeKota:      "Kota",
+	CityTypeKabupaten: "Kabupaten",
+}
+
+// ParseCityType parses the type as returned by the API, ignoring case
+func ParseCityType(s string) (CityType, error) {
+	for t, name := range cityTypeNames {
+		if strings.EqualFold(s, name) {
+			return t, nil
+		}
+	}
+	return CityTypeUnknown, fmt.Errorf("rajaongkir: unknown city type %q", s)
+}
+
+// String returns the type as written by the API
+func (t CityType) String() string {
+	return cityTypeNames[t]
+}
+
+// MarshalJSON encodes t as the API does, e.g. "Kota"
+func (t CityType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON decodes "Kota" or "Kabupaten".
+// Any other type decodes to CityTypeUnknown, so a new type in the API
+// does not fail the whole response
+func (t *CityType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*t, _ = ParseCityType(s)
+	return nil
+}
+
+// CityID identifies a city
+type CityID string
+
+// ProvinceID identifies a province
+type ProvinceID string
+
+// validateID checks that id is a positive number without leading zeros
+func validateID(kind, id string) error {
+	if id == "" {
+		return fmt.Errorf("rajaongkir: %s ID must be specified", kind)
+	}
+	if id[0] == '0' {
+		return fmt.Errorf("rajaongkir: invalid %s ID %q", kind, id)
+	}
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return fmt.Errorf("rajaongkir: invalid %s ID %q", kind, id)
+		}
+	}
+	return nil
+}
+
+// ParseCityID validates s as a city ID
+func ParseCityID(s string) (CityID, error) {
+	id := CityID(strings.TrimSpace(s))
+	return id, id.Validate()
+}
+
+// Validate checks that id is a positive number
+func (id CityID) Validate() error {
+	return validateID("city", string(id))
+}
+
+// String returns id as the API expects it
+func (id CityID) String() string {
+	return string(id)
+}
+
+// UnmarshalJSON decodes a city ID as is, use Validate to check it
+func (id *CityID) UnmarshalJSON(data []byte) error {
+	s, err := unmarshalID(data)
+	*id = CityID(s)
+	return err
+}
+
+// ParseProvinceID validates s as a province ID
+func ParseProvinceID(s string) (ProvinceID, error) {
+	id := ProvinceID(strings.TrimSpace(s))
+	return id, id.Validate()
+}
+
+// Validate checks that id is a positive number
+func (id ProvinceID) Validate() error {
+	return validateID("province", string(id))
+}
+
+// String returns id as the API expects it
+func (id ProvinceID) String() string {
+	return string(id)
+}
+
+// UnmarshalJSON decodes a province ID as is, use Validate to check it
+func (id *ProvinceID) UnmarshalJSON(data []byte) error {
+	s, err := unmarshalID(data)
+	*id = ProvinceID(s)
+	return err
+}
+
+// unmarshalID accepts IDs encoded as strings, as the API does, or as numbers
+func unmarshalID(data []byte) (string, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return s, nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return "", err
+	}
+	return n.String(), nil
+}
+
+// DisplayName returns the name of the city prefixed with its type,
+// e.g. "Kota Yogyakarta" or "Kabupaten Bantul"
+func (c City) DisplayName() string {
+	if c.Type == CityTypeUnknown {
+		return c.CityName
+	}
+	return c.Type.String() + " " + c.CityName
+}
@@ -0,0 +1,102 @@
+package rajaongkir
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCityTypeJSON(t *testing.T) {
+	tables := []struct {
+		json     string
+		expected CityType
+		isErr    bool
+	}{
+		{`"Kota"`, CityTypeKota, false},
+		{`"Kabupaten"`, CityTypeKabupaten, false},
+		{`"kabupaten"`, CityTypeKabupaten, false},
+		{`""`, CityTypeUnknown, false},
+		{`"Desa"`, CityTypeUnknown, false},
+		{`1`, CityTypeUnknown, true},
+	}
+
+	for _, table := range tables {
+		var ct CityType
+		err := json.Unmarshal([]byte(table.json), &ct)
+		if (err != nil) != table.isErr {
+			t.Errorf("Error mismatch for %s. Got %v, expected %v", table.json, err, table.isErr)
+		}
+		if ct != table.expected {
+			t.Errorf("Wrong type for %s. Got %s, expected %s", table.json, ct, table.expected)
+		}
+	}
+
+	data, _ := json.Marshal(City{CityID: "39", Type: CityTypeKabupaten})
+	expected := `{"city_id":"39","province_id":"","province":"","type":"Kabupaten","city_name":"","postal_code":""}`
+	if string(data) != expected {
+		t.Errorf("Wrong JSON. Got %s, expected %s", data, expected)
+	}
+}
+
+func TestIDs(t *testing.T) {
+	tables := []struct {
+		id    string
+		isErr bool
+	}{
+		{"39", false},
+		{" 501 ", false},
+		{"", true},
+		{"039", true},
+		{"-1", true},
+		{"3a", true},
+	}
+
+	for _, table := range tables {
+		_, err := ParseCityID(table.id)
+		if (err != nil) != table.isErr {
+			t.Errorf("Error mismatch for city ID %q. Got %v, expected %v", table.id, err, table.isErr)
+		}
+		_, err = ParseProvinceID(table.id)
+		if (err != nil) != table.isErr {
+			t.Errorf("Error mismatch for province ID %q. Got %v, expected %v", table.id, err, table.isErr)
+		}
+	}
+}
+
+func TestDecodeTypedCity(t *testing.T) {
+	re := &cityResponse{}
+	if err := json.Unmarshal([]byte(cityRes), re); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	city := re.Rajaongkir.Results
+	if city.CityID != "39" || city.ProvinceID != "5" || city.Type != CityTypeKabupaten {
+		t.Errorf("Wrong city decoded. Got %+v", city)
+	}
+
+	var c City
+	if err := json.Unmarshal([]byte(`{"city_id":39,"province_id":"5"}`), &c); err != nil || c.CityID != "39" {
+		t.Errorf("Expected numeric IDs to be accepted. Got %v (%v)", c.CityID, err)
+	}
+	if err := json.Unmarshal([]byte(`{"city_id":"abc","province_id":"05","type":"Desa"}`), &c); err != nil {
+		t.Errorf("Expected unexpected values to be decoded. Got %v", err)
+	}
+	if c.CityID != "abc" || c.ProvinceID != "05" || c.Type != CityTypeUnknown {
+		t.Errorf("Wrong city decoded. Got %+v, expected the raw IDs and an unknown type", c)
+	}
+}
+
+func TestDisplayName(t *testing.T) {
+	tables := []struct {
+		city     City
+		expected string
+	}{
+		{City{Type: CityTypeKota, CityName: "Yogyakarta"}, "Kota Yogyakarta"},
+		{City{Type: CityTypeKabupaten, CityName: "Bantul"}, "Kabupaten Bantul"},
+		{City{CityName: "Bantul"}, "Bantul"},
+	}
+
+	for _, table := range tables {
+		if result := table.city.DisplayName(); result != table.expected {
+			t.Errorf("Wrong display name. Got %s, expected %s", result, table.expected)
+		}
+	}
+}
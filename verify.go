@@ -0,0 +1,147 @@
+package rajaongkir
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Errors returned by Verify
+var (
+	ErrInvalidKey = errors.New("rajaongkir: API key rejected")
+	ErrWrongTier  = errors.New("rajaongkir: API key is for another tier")
+)
+
+// KeyStatus is what Verify found out about an API key
+type KeyStatus struct {
+	// Key is redacted to its last four characters
+	Key   string
+	Valid bool
+	// Tier is inferred from the endpoints the key may use on the client's base URL
+	Tier Tier
+	// Description is the reason RajaOngkir gave for rejecting an invalid key
+	Description string
+}
+
+type probeResponse struct {
+	Rajaongkir struct {
+		Status status `json:"status"`
+	} `json:"rajaongkir"`
+}
+
+func (re *probeResponse) responseStatus() *status { return &re.Rajaongkir.Status }
+
+// Verify checks every API key of the client, the one given to New and those pooled WithAPIKeys,
+// by fetching a province, then infers their tier by probing the waybill and subdistrict endpoints.
+// It returns a status per key in the order of KeyStats. The probes count towards the usage
+// of the pool, and a key RajaOngkir rejects rests as it would after any other call.
+//
+// RajaOngkir serves every account type under its own base URL, and the probes only see
+// the endpoints of the client's one: a basic key used with the starter base URL is
+// reported as starter, since the starter API has no waybill endpoint.
+//
+// The error joins one wrapping ErrInvalidKey for every key RajaOngkir rejects,
+// and one wrapping ErrWrongTier for every key of another tier if the client was created WithTier.
+// Call it at startup to fail early with a clear message
+func (r *RajaOngkir) Verify(ctx context.Context) (statuses []KeyStatus, err error) {
+	ctx, span := r.startSpan(ctx, "Verify")
+	defer func() { finishSpan(span, err) }()
+
+	keys := []string{r.apiKey}
+	if r.keys != nil {
+		keys = r.keys.list()
+	}
+	if len(keys) == 0 || keys[0] == "" {
+		return nil, ErrNoAPIKey
+	}
+	span.SetAttribute("rajaongkir.keys", len(keys))
+	errs := []error{}
+	for _, key := range keys {
+		ks, keyErr := r.verifyKey(ctx, key)
+		if keyErr != nil && !errors.Is(keyErr, ErrInvalidKey) && !errors.Is(keyErr, ErrWrongTier) {
+			return statuses, keyErr
+		}
+		statuses = append(statuses, ks)
+		switch {
+		case keyErr != nil && len(keys) > 1:
+			errs = append(errs, fmt.Errorf("key %s: %w", ks.Key, keyErr))
+		case keyErr != nil:
+			errs = append(errs, keyErr)
+		}
+	}
+	return statuses, errors.Join(errs...)
+}
+
+// verifyKey checks key and infers its tier
+func (r *RajaOngkir) verifyKey(ctx context.Context, key string) (KeyStatus, error) {
+	ks := KeyStatus{Key: redactKey(key)}
+	re := &probeResponse{}
+	err := r.verifyCall(ctx, key, http.MethodGet, provinceEndpoint+"?id=1", "", re)
+	if err != nil {
+		return ks, err
+	}
+	s := re.responseStatus()
+	if r.keys != nil {
+		r.keys.done(key, keyRejection(s))
+	}
+	if rejectsKey(s) {
+		ks.Description = s.Description
+		return ks, fmt.Errorf("%w: %s", ErrInvalidKey, s.Description)
+	}
+	if err := checkStatus(s); err != nil {
+		return ks, err
+	}
+
+	ks.Valid, ks.Tier = true, TierStarter
+	probes := []struct {
+		method, endpoint, payload string
+		tier                      Tier
+	}{
+		{http.MethodPost, waybillEndpoint, "waybill=VERIFY&courier=jne", TierBasic},
+		{http.MethodGet, subdistrictEndpoint + "?city=1", "", TierPro},
+	}
+	for _, p := range probes {
+		ok, err := r.probe(ctx, key, p.method, p.endpoint, p.payload)
+		if err != nil {
+			return KeyStatus{Key: ks.Key}, err
+		}
+		if !ok {
+			break
+		}
+		ks.Tier = p.tier
+	}
+	if r.tier != "" && r.tier != ks.Tier {
+		return ks, fmt.Errorf("%w: client is configured for %s, key is for %s", ErrWrongTier, r.tier, ks.Tier)
+	}
+	return ks, nil
+}
+
+// probe reports whether key may use endpoint.
+// Any answer other than a missing or forbidden endpoint means it may,
+// even if the probe itself is rejected as invalid
+func (r *RajaOngkir) probe(ctx context.Context, key, method, endpoint, payload string) (bool, error) {
+	re := &probeResponse{}
+	err := r.verifyCall(ctx, key, method, endpoint, payload, re)
+	var responseErr *ResponseError
+	if errors.As(err, &responseErr) && (responseErr.StatusCode == http.StatusNotFound || responseErr.StatusCode == http.StatusForbidden) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	s := re.responseStatus()
+	if s.Code == http.StatusNotFound || s.Code == http.StatusForbidden || rejectsKey(s) {
+		return false, nil
+	}
+	return !strings.Contains(strings.ToLower(s.Description), "endpoint"), nil
+}
+
+// verifyCall makes a probe with key, counted in the usage of the pool if there is one
+func (r *RajaOngkir) verifyCall(ctx context.Context, key, method, endpoint, payload string, vs interface{}) error {
+	if r.keys != nil {
+		r.keys.use(key)
+	}
+	return r.attempt(ctx, key, 1, method, endpoint, payload, vs)
+}
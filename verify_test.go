@@ -0,0 +1,79 @@
+package rajaongkir_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	rajaongkir "github.com/GreenGeorge/go-rajaongkir"
+	"github.com/GreenGeorge/go-rajaongkir/rajaongkirtest"
+)
+
+func TestVerify(t *testing.T) {
+	for _, tier := range []rajaongkir.Tier{rajaongkir.TierStarter, rajaongkir.TierBasic, rajaongkir.TierPro} {
+		srv := rajaongkirtest.NewServer(rajaongkirtest.WithTier(tier))
+		statuses, err := srv.NewClient().Verify(context.Background())
+		srv.Close()
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %s", tier, err)
+		}
+		expected := []rajaongkir.KeyStatus{{Key: "***TKEY", Valid: true, Tier: tier}}
+		if len(statuses) != 1 || statuses[0] != expected[0] {
+			t.Errorf("Wrong key status. Got %+v, expected %+v", statuses, expected)
+		}
+	}
+}
+
+func TestVerifyErrors(t *testing.T) {
+	srv := rajaongkirtest.NewServer(rajaongkirtest.WithKeys("OTHERKEY"))
+	statuses, err := srv.NewClient().Verify(context.Background())
+	srv.Close()
+	if !errors.Is(err, rajaongkir.ErrInvalidKey) || len(statuses) != 1 || statuses[0].Valid || !strings.HasPrefix(statuses[0].Description, "Invalid key.") {
+		t.Errorf("Expected the key to be rejected. Got %+v, %v", statuses, err)
+	}
+
+	srv = rajaongkirtest.NewServer()
+	statuses, err = srv.NewClient(rajaongkir.WithTier(rajaongkir.TierBasic)).Verify(context.Background())
+	srv.Close()
+	if !errors.Is(err, rajaongkir.ErrWrongTier) || len(statuses) != 1 || !statuses[0].Valid || statuses[0].Tier != rajaongkir.TierStarter {
+		t.Errorf("Expected a tier mismatch. Got %+v, %v", statuses, err)
+	}
+	if err != nil && err.Error() != "rajaongkir: API key is for another tier: client is configured for basic, key is for starter" {
+		t.Errorf("Wrong error message. Got %q", err)
+	}
+}
+
+func TestVerifyKeys(t *testing.T) {
+	srv := rajaongkirtest.NewServer(rajaongkirtest.WithTier(rajaongkir.TierPro), rajaongkirtest.WithKeys("FIRSTKEY", "SECONDKEY"))
+	defer srv.Close()
+	ro := rajaongkir.New("FIRSTKEY", srv.BaseURL(), srv.Client(), rajaongkir.WithAPIKeys(rajaongkir.RoundRobin, "SECONDKEY", "THIRDKEY"))
+
+	statuses, err := ro.Verify(context.Background())
+	expected := []rajaongkir.KeyStatus{
+		{Key: "****TKEY", Valid: true, Tier: rajaongkir.TierPro},
+		{Key: "*****DKEY", Valid: true, Tier: rajaongkir.TierPro},
+		{Key: "****DKEY", Description: "Invalid key. API key tidak ditemukan di database RajaOngkir."},
+	}
+	if len(statuses) != len(expected) {
+		t.Fatalf("Wrong number of key statuses. Got %+v, expected %+v", statuses, expected)
+	}
+	for i := range expected {
+		if statuses[i] != expected[i] {
+			t.Errorf("Wrong status for key %d. Got %+v, expected %+v", i, statuses[i], expected[i])
+		}
+	}
+	if !errors.Is(err, rajaongkir.ErrInvalidKey) || !strings.HasPrefix(err.Error(), "key ****DKEY: ") {
+		t.Errorf("Expected the third key to be rejected. Got %v", err)
+	}
+
+	stats := ro.KeyStats()
+	for i, requests := range []int{3, 3, 1} {
+		if stats[i].Requests != requests {
+			t.Errorf("Wrong requests for %s. Got %d, expected %d", stats[i].Key, stats[i].Requests, requests)
+		}
+	}
+	if stats[2].Rejections != 1 || stats[2].RestingUntil.IsZero() {
+		t.Errorf("Expected the rejected key to rest. Got %+v", stats[2])
+	}
+}
@@ -0,0 +1,102 @@
+package rajaongkir
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Waybill tracking is only available on the Basic and Pro tiers,
+// see https://rajaongkir.com/dokumentasi/basic
+const waybillEndpoint = "/waybill"
+
+// Waybill stores the tracking details of a shipment
+type Waybill struct {
+	Delivered      bool           `json:"delivered"`
+	Summary        WaybillSummary `json:"summary"`
+	Details        WaybillDetails `json:"details"`
+	DeliveryStatus DeliveryStatus `json:"delivery_status"`
+	Manifest       []Manifest     `json:"manifest"`
+}
+
+// WaybillSummary stores the overview of a shipment
+type WaybillSummary struct {
+	CourierCode   string `json:"courier_code"`
+	CourierName   string `json:"courier_name"`
+	WaybillNumber string `json:"waybill_number"`
+	ServiceCode   string `json:"service_code"`
+	WaybillDate   string `json:"waybill_date"`
+	ShipperName   string `json:"shipper_name"`
+	ReceiverName  string `json:"receiver_name"`
+	Origin        string `json:"origin"`
+	Destination   string `json:"destination"`
+	Status        string `json:"status"`
+}
+
+// WaybillDetails stores where a shipment was sent from and to
+type WaybillDetails struct {
+	WaybillNumber string `json:"waybill_number"`
+	WaybillDate   string `json:"waybill_date"`
+	WaybillTime   string `json:"waybill_time"`
+	Weight        string `json:"weight"`
+	Origin        string `json:"origin"`
+	Destination   string `json:"destination"`
+	ReceiverName  string `json:"receiver_name"`
+	ReceiverCity  string `json:"receiver_city"`
+}
+
+// DeliveryStatus stores the proof of delivery of a shipment
+type DeliveryStatus struct {
+	Status      string `json:"status"`
+	PODReceiver string `json:"pod_receiver"`
+	PODDate     string `json:"pod_date"`
+	PODTime     string `json:"pod_time"`
+}
+
+// Manifest is a step in the journey of a shipment
+type Manifest struct {
+	ManifestCode        string `json:"manifest_code"`
+	ManifestDescription string `json:"manifest_description"`
+	ManifestDate        string `json:"manifest_date"`
+	ManifestTime        string `json:"manifest_time"`
+	CityName            string `json:"city_name"`
+}
+
+type waybillResponse struct {
+	Rajaongkir struct {
+		Query  query   `json:"query"`
+		Status status  `json:"status"`
+		Result Waybill `json:"result"`
+	} `json:"rajaongkir"`
+}
+
+func (re *waybillResponse) responseStatus() *status { return &re.Rajaongkir.Status }
+
+// GetWaybill tracks the shipment with the given waybill number
+// sent with courier. Requires a Basic or Pro API key
+func (r *RajaOngkir) GetWaybill(waybill, courier string) (Waybill, error) {
+	return r.GetWaybillContext(context.Background(), waybill, courier)
+}
+
+// GetWaybillContext is like GetWaybill but carries ctx
+func (r *RajaOngkir) GetWaybillContext(ctx context.Context, waybill, courier string) (result Waybill, err error) {
+	if waybill == "" || courier == "" {
+		return Waybill{}, fmt.Errorf("waybill/courier must be specified")
+	}
+	ctx, span := r.startSpan(ctx, "GetWaybill")
+	defer func() { finishSpan(span, err) }()
+	span.SetAttribute("rajaongkir.courier", courier)
+	queryString := url.Values{"waybill": {waybill}, "courier": {courier}}.Encode()
+	re := &waybillResponse{}
+	err = r.sendRequest(ctx, http.MethodPost, waybillEndpoint, queryString, re)
+	if err != nil {
+		return Waybill{}, err
+	}
+	err = checkStatus(&re.Rajaongkir.Status)
+	if err != nil {
+		return Waybill{}, err
+	}
+	result = re.Rajaongkir.Result
+	return result, nil
+}
@@ -0,0 +1,74 @@
+package rajaongkir
+
+import "testing"
+
+const waybillRes string = `{
+    "rajaongkir": {
+        "query": {
+            "waybill": "SOCAG00183235715",
+            "courier": "jne"
+        },
+        "status": {
+            "code": 200,
+            "description": "OK"
+        },
+        "result": {
+            "delivered": true,
+            "summary": {
+                "courier_code": "jne",
+                "courier_name": "Jalur Nugraha Ekakurir (JNE)",
+                "waybill_number": "SOCAG00183235715",
+                "service_code": "REG",
+                "waybill_date": "2020-10-01",
+                "shipper_name": "TOKO SEBELAH",
+                "receiver_name": "BUDI",
+                "origin": "YOGYAKARTA",
+                "destination": "DENPASAR",
+                "status": "DELIVERED"
+            },
+            "delivery_status": {
+                "status": "DELIVERED",
+                "pod_receiver": "BUDI",
+                "pod_date": "2020-10-03",
+                "pod_time": "14:12"
+            },
+            "manifest": [
+                {
+                    "manifest_code": "1",
+                    "manifest_description": "Manifested",
+                    "manifest_date": "2020-10-01",
+                    "manifest_time": "18:01",
+                    "city_name": "YOGYAKARTA"
+                }
+            ]
+        }
+    }
+}`
+
+func TestGetWaybill(t *testing.T) {
+	ts, ro, rec := setupTest(waybillRes)
+	defer ts.Close()
+	waybill, err := ro.GetWaybill("SOCAG00183235715", "jne")
+	expectedMethod := "POST"
+	expectedAPIKey := "APIKEY12345"
+	expectedEndpoint := "/waybill"
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if rec.receivedMethod != expectedMethod {
+		t.Errorf("Wrong method. Received %s, expected %s", rec.receivedMethod, expectedMethod)
+	}
+	if rec.receivedAPIKey != expectedAPIKey {
+		t.Errorf("Wrong APIKEY. Received %s, expected %s", rec.receivedAPIKey, expectedAPIKey)
+	}
+	if rec.receivedEndpoint != expectedEndpoint {
+		t.Errorf("Wrong endpoint. Received %s, expected %s", rec.receivedEndpoint, expectedEndpoint)
+	}
+	if !waybill.Delivered || waybill.Summary.Status != "DELIVERED" || len(waybill.Manifest) != 1 {
+		t.Errorf("Wrong waybill decoded. Got %+v", waybill)
+	}
+	if _, err := ro.GetWaybill("", "jne"); err == nil {
+		t.Errorf("Expected an error for a missing waybill number")
+	}
+}
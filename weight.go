@@ -0,0 +1,94 @@
+package rajaongkir
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Errors returned by Weight.Validate
+var (
+	ErrInvalidWeight = errors.New("weight must be positive")
+	ErrWeightLimit   = errors.New("weight exceeds the tier limit")
+)
+
+// Weight is a mass in grams, the unit RajaOngkir expects
+type Weight int
+
+// Grams returns a Weight of g grams
+func Grams(g int) Weight {
+	return Weight(g)
+}
+
+// Kilograms returns a Weight of kg kilograms, rounded to the nearest gram
+func Kilograms(kg float64) Weight {
+	return Weight(math.Round(kg * 1000))
+}
+
+// Grams returns w in grams
+func (w Weight) Grams() int {
+	return int(w)
+}
+
+// Kilograms returns w in kilograms
+func (w Weight) Kilograms() float64 {
+	return float64(w) / 1000
+}
+
+// String formats w as grams below a kilogram and as kilograms above, e.g. "850 g" or "1.7 kg"
+func (w Weight) String() string {
+	if w < 1000 && w > -1000 {
+		return strconv.Itoa(int(w)) + " g"
+	}
+	return strconv.FormatFloat(w.Kilograms(), 'f', -1, 64) + " kg"
+}
+
+// courierRoundings is the weight in grams each courier bills in started steps of
+var courierRoundings = map[string]Weight{
+	"jne":  1000,
+	"pos":  1000,
+	"tiki": 1000,
+}
+
+// Billable returns the weight courier charges for w.
+// JNE, POS and TIKI round up to the next started kilogram,
+// other couriers are returned as is
+func (w Weight) Billable(courier string) Weight {
+	step, ok := courierRoundings[strings.ToLower(courier)]
+	if !ok || w <= 0 {
+		return w
+	}
+	return (w + step - 1) / step * step
+}
+
+// tierMaxWeights is the heaviest weight in grams a single cost request may carry
+var tierMaxWeights = map[Tier]Weight{
+	TierStarter: DefaultMaxParcelWeight,
+	TierBasic:   DefaultMaxParcelWeight,
+}
+
+// MaxWeight returns the heaviest weight t quotes in a single request, 0 meaning no limit
+func (t Tier) MaxWeight() Weight {
+	return tierMaxWeights[t]
+}
+
+// Validate checks that w is positive and within the limit of t
+func (w Weight) Validate(t Tier) error {
+	if w <= 0 {
+		return ErrInvalidWeight
+	}
+	if limit := t.MaxWeight(); limit > 0 && w > limit {
+		return fmt.Errorf("%w: %s is over %s on %s", ErrWeightLimit, w, limit, t)
+	}
+	return nil
+}
+
+// WithTier sets the account type of the API key,
+// so GetCost can reject weights over the tier limit before sending the request
+func WithTier(t Tier) Option {
+	return func(r *RajaOngkir) {
+		r.tier = t
+	}
+}
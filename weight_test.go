@@ -0,0 +1,78 @@
+package rajaongkir
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWeight(t *testing.T) {
+	tables := []struct {
+		weight   Weight
+		grams    int
+		str      string
+		billable Weight
+	}{
+		{Grams(1700), 1700, "1.7 kg", 2000},
+		{Kilograms(1.7), 1700, "1.7 kg", 2000},
+		{Kilograms(0.0004), 0, "0 g", 0},
+		{Grams(850), 850, "850 g", 1000},
+		{Kilograms(2), 2000, "2 kg", 2000},
+	}
+
+	for _, table := range tables {
+		if table.weight.Grams() != table.grams {
+			t.Errorf("Wrong grams. Got %d, expected %d", table.weight.Grams(), table.grams)
+		}
+		if table.weight.String() != table.str {
+			t.Errorf("Wrong string. Got %s, expected %s", table.weight, table.str)
+		}
+		if b := table.weight.Billable("jne"); b != table.billable {
+			t.Errorf("Wrong billable weight for %s on jne. Got %s, expected %s", table.weight, b, table.billable)
+		}
+	}
+	if b := Grams(1700).Billable("sicepat"); b != 1700 {
+		t.Errorf("Wrong billable weight for an unknown courier. Got %s, expected 1.7 kg", b)
+	}
+}
+
+func TestWeightValidate(t *testing.T) {
+	tables := []struct {
+		weight   Weight
+		tier     Tier
+		expected error
+	}{
+		{Grams(1700), TierStarter, nil},
+		{Grams(30000), TierStarter, nil},
+		{Grams(30001), TierStarter, ErrWeightLimit},
+		{Grams(30001), TierBasic, ErrWeightLimit},
+		{Grams(50000), TierPro, nil},
+		{Grams(50000), "", nil},
+		{Grams(0), TierPro, ErrInvalidWeight},
+		{Grams(-5), "", ErrInvalidWeight},
+	}
+
+	for _, table := range tables {
+		err := table.weight.Validate(table.tier)
+		if !errors.Is(err, table.expected) {
+			t.Errorf("Wrong error for %s on %s. Got %v, expected %v", table.weight, table.tier, err, table.expected)
+		}
+	}
+}
+
+func TestGetCostValidatesWeight(t *testing.T) {
+	ts, ro, rec := setupTest(costRes, WithTier(TierStarter))
+	defer ts.Close()
+
+	_, err := ro.GetCost("501", "114", 31000, "jne")
+	if !errors.Is(err, ErrWeightLimit) {
+		t.Errorf("Expected ErrWeightLimit. Got %v", err)
+	}
+	if rec.receivedEndpoint != "" {
+		t.Errorf("Expected no request to be sent. Got %s", rec.receivedEndpoint)
+	}
+
+	_, err = ro.GetCost("501", "114", 30000, "jne")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}